@@ -19,6 +19,17 @@ const (
 	marketOptionsURI = "marketdata/options/"  //{_optionid}/
 	oAuthUpgradeURI  = "oauth2/migrate_token/"
 	ordersURI        = "orders/"
+	fundamentalsURI  = "fundamentals/" // ?symbols=
+	instrumentsURI   = "instruments/"  // ?symbol= or ?query=
+	marketsURI       = "markets/"      // {_mic}/hours/{_date}/
+	optionPosURI     = "options/positions/"
+	portfoliosURI    = "portfolios/"
+	historicalsURI   = "portfolios/historicals/" // {_account}/?span=&interval=
+	dividendsURI     = "dividends/"
+	achRelationsURI  = "ach/relationships/"
+	achTransfersURI  = "ach/transfers/"
+	optionOrdersURI  = "options/orders/"
+	optionEventsURI  = "options/events/"
 )
 
 // get performs an HTTP get request on 'endpoint'..
@@ -44,8 +55,12 @@ func (c *Client) post(endpoint string, data string) ([]byte, error) {
 
 func (c *Client) doReqWithAuth(req *http.Request) ([]byte, error) {
 	_, hasAuthorizationHeader := req.Header["Authorization"]
-	if c.Token != "" && !hasAuthorizationHeader {
-		req.Header.Add("Authorization", "Token "+c.Token)
+	token := c.Token
+	if token == "" && c.parent != nil {
+		token = c.parent.Token
+	}
+	if token != "" && !hasAuthorizationHeader {
+		req.Header.Add("Authorization", "Token "+token)
 	}
 	return c.doReq(req)
 }
@@ -53,10 +68,10 @@ func (c *Client) doReqWithAuth(req *http.Request) ([]byte, error) {
 func (c *Client) doReq(req *http.Request) ([]byte, error) {
 	req.Header.Add("Accept", "application/json")
 	// Ensure we have an HTTP client on the first request.
-	c.once.Do(func() {
-		c.httpClient = &http.Client{}
-	})
+	c.init()
 	//log.Printf("\n\n== req:\n%v\n", req)
+	c.inFlight <- struct{}{}
+	defer func() { <-c.inFlight }()
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
 		return nil, err
@@ -74,10 +89,10 @@ func (c *Client) doReq(req *http.Request) ([]byte, error) {
 }
 
 func (c *Client) doReqWithBearerToken(req *http.Request) ([]byte, error) {
-	err := c.EnsureBearerToken()
+	token, err := c.bearerToken()
 	if err != nil {
 		return nil, err
 	}
-	req.Header.Add("Authorization", "Bearer "+c.BearerToken)
+	req.Header.Add("Authorization", "Bearer "+token)
 	return c.doReq(req)
 }
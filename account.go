@@ -0,0 +1,345 @@
+package robinhood
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// This file deals with account balances and portfolio values.
+
+// Account is a brokerage account of the user. It is encoded to JSON with the
+// API's field names, and decoded from the API's representation, in which
+// amounts are strings.
+type Account struct {
+	AccountNumber string `json:"account_number"`
+	URL           string `json:"url"`
+	Type          string `json:"type"` // "cash" or "margin".
+	Deactivated   bool   `json:"deactivated"`
+	// OptionLevel is the level of options trading the account is approved
+	// for, e.g. "option_level_2". Empty if not approved.
+	OptionLevel string `json:"option_level"`
+
+	BuyingPower       float64 `json:"buying_power"`
+	Cash              float64 `json:"cash"`
+	CashHeldForOrders float64 `json:"cash_held_for_orders"`
+	// UnsettledFunds are proceeds of sales that haven't settled yet, and
+	// UnsettledDebit purchases that haven't.
+	UnsettledFunds    float64 `json:"unsettled_funds"`
+	UnsettledDebit    float64 `json:"unsettled_debit"`
+	UnclearedDeposits float64 `json:"uncleared_deposits"`
+	SMA               float64 `json:"sma"` // Special memorandum account.
+
+	// Margin is the margin state of margin accounts. It is zero for cash
+	// accounts.
+	Margin MarginBalances `json:"margin_balances"`
+
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// MarginBalances is the margin state of a margin account.
+type MarginBalances struct {
+	Limit                float64 `json:"margin_limit"` // Maximum margin available.
+	UnallocatedCash      float64 `json:"unallocated_margin_cash"`
+	DayTradeBuyingPower  float64 `json:"day_trade_buying_power"`
+	OvernightBuyingPower float64 `json:"overnight_buying_power"`
+	DayTradeRatio        float64 `json:"day_trade_ratio"`
+	OvernightRatio       float64 `json:"overnight_ratio"`
+	// MarkedPatternDayTraderDate is when the account was flagged as a pattern
+	// day trader. Zero if it never was.
+	MarkedPatternDayTraderDate time.Time `json:"marked_pattern_day_trader_date"`
+}
+
+// GetAccounts returns the list of all accounts associated with a user, with
+// their balances. Client must be authenticated (i.e. a Token must be
+// supplied).
+//
+// If some accounts can't be parsed, GetAccounts returns all other accounts
+// along with an *UnparsedAccountsError listing them.
+func (c *Client) GetAccounts() ([]Account, error) {
+	resp, err := c.paginatedGet(accountsURI)
+	if err != nil {
+		return nil, err
+	}
+	var raws []json.RawMessage
+	err = json.Unmarshal(resp, &raws)
+	if err != nil {
+		return nil, err
+	}
+	var accounts []Account
+	var unparsed *UnparsedAccountsError
+	for _, raw := range raws {
+		var a account
+		err := json.Unmarshal(raw, &a)
+		var acc Account
+		if err == nil {
+			acc, err = newAccount(a)
+		}
+		if err != nil {
+			if unparsed == nil {
+				unparsed = &UnparsedAccountsError{}
+			}
+			unparsed.Accounts = append(unparsed.Accounts, a.AccountNumber)
+			unparsed.Errs = append(unparsed.Errs, err)
+			continue
+		}
+		accounts = append(accounts, acc)
+	}
+	if unparsed != nil {
+		return accounts, unparsed
+	}
+	return accounts, nil
+}
+
+// UnparsedAccountsError is returned by GetAccounts, along with all other
+// accounts, when some accounts can't be parsed.
+type UnparsedAccountsError struct {
+	Accounts []string // Account numbers, empty if unknown.
+	Errs     []error  // Errs[i] is the error parsing Accounts[i].
+}
+
+// Error implements error.
+func (e *UnparsedAccountsError) Error() string {
+	return fmt.Sprintf("could not parse %d accounts: %v", len(e.Accounts), e.Errs[0])
+}
+
+// accountURL returns the URL of the client's account, AccountID. Only the
+// numbers and URLs of accounts are decoded, so that balances that can't be
+// parsed don't get in the way.
+func (c *Client) accountURL() (string, error) {
+	resp, err := c.paginatedGet(accountsURI)
+	if err != nil {
+		return "", err
+	}
+	var accs []struct {
+		AccountNumber string `json:"account_number"`
+		URL           string `json:"url"`
+	}
+	err = json.Unmarshal(resp, &accs)
+	if err != nil {
+		return "", err
+	}
+	for _, a := range accs {
+		if a.AccountNumber == c.AccountID {
+			return a.URL, nil
+		}
+	}
+	return "", fmt.Errorf("invalid account number %s", c.AccountID)
+}
+
+// Account returns the client's account, AccountID, with its balances.
+func (c *Client) Account() (Account, error) {
+	resp, err := c.get(accountsURI + c.AccountID + "/")
+	if err != nil {
+		return Account{}, err
+	}
+	var a account
+	err = json.Unmarshal(resp, &a)
+	if err != nil {
+		return Account{}, err
+	}
+	return newAccount(a)
+}
+
+// AccountID returns the account number in the URL of an account, e.g.
+// "5RY82436" for "https://api.robinhood.com/accounts/5RY82436/".
+func AccountID(u string) string {
+	u = strings.TrimSuffix(u, "/")
+	return u[strings.LastIndex(u, "/")+1:]
+}
+
+// inAccount returns whether u, the URL of an account, is the client's
+// account, AccountID. Every account is if AccountID is empty.
+func (c *Client) inAccount(u string) bool {
+	return c.AccountID == "" || AccountID(u) == c.AccountID
+}
+
+// DayTradeCount returns the number of day trades, of stocks and options, made
+// in the client's account in the last five trading days. Accounts with four
+// or more are flagged as pattern day traders.
+func (c *Client) DayTradeCount() (int, error) {
+	resp, err := c.get(accountsURI + c.AccountID + "/recent_day_trades/")
+	if err != nil {
+		return 0, err
+	}
+	var trades struct {
+		Equity []json.RawMessage `json:"equity_day_trades"`
+		Option []json.RawMessage `json:"option_day_trades"`
+	}
+	err = json.Unmarshal(resp, &trades)
+	if err != nil {
+		return 0, err
+	}
+	return len(trades.Equity) + len(trades.Option), nil
+}
+
+// newAccount converts a to the external format.
+// UnmarshalJSON implements json.Unmarshaler, decoding an account as returned
+// by the API.
+func (acc *Account) UnmarshalJSON(b []byte) error {
+	var a account
+	err := json.Unmarshal(b, &a)
+	if err != nil {
+		return err
+	}
+	*acc, err = newAccount(a)
+	return err
+}
+
+func newAccount(a account) (Account, error) {
+	buyingPower, err := parseOptionalFloat64(a.BuyingPower, nil)
+	cash, err := parseOptionalFloat64(a.Cash, err)
+	held, err := parseOptionalFloat64(a.CashHeldForOrders, err)
+	unsettledFunds, err := parseOptionalFloat64(a.UnsettledFunds, err)
+	unsettledDebit, err := parseOptionalFloat64(a.UnsettledDebit, err)
+	uncleared, err := parseOptionalFloat64(a.UnclearedDeposits, err)
+	sma, err := parseOptionalFloat64(a.SMA, err)
+	createdAt, err := parseTime(a.CreatedAt, err)
+	updatedAt, err := parseTime(a.UpdatedAt, err)
+	acc := Account{
+		AccountNumber:     a.AccountNumber,
+		URL:               a.URL,
+		Type:              a.Type,
+		Deactivated:       a.Deactivated,
+		OptionLevel:       a.OptionLevel,
+		BuyingPower:       buyingPower,
+		Cash:              cash,
+		CashHeldForOrders: held,
+		UnsettledFunds:    unsettledFunds,
+		UnsettledDebit:    unsettledDebit,
+		UnclearedDeposits: uncleared,
+		SMA:               sma,
+		CreatedAt:         createdAt,
+		UpdatedAt:         updatedAt,
+	}
+	if m := a.MarginBalances; m != nil && err == nil {
+		acc.Margin.Limit, err = parseOptionalFloat64(m.Limit, err)
+		acc.Margin.UnallocatedCash, err = parseOptionalFloat64(m.UnallocatedCash, err)
+		acc.Margin.DayTradeBuyingPower, err = parseOptionalFloat64(m.DayTradeBuyingPower, err)
+		acc.Margin.OvernightBuyingPower, err = parseOptionalFloat64(m.OvernightBuyingPower, err)
+		acc.Margin.DayTradeRatio, err = parseOptionalFloat64(m.DayTradeRatio, err)
+		acc.Margin.OvernightRatio, err = parseOptionalFloat64(m.OvernightRatio, err)
+		if m.MarkedPatternDayTraderDate != "" && err == nil {
+			acc.Margin.MarkedPatternDayTraderDate, err = time.Parse(dateFormat, m.MarkedPatternDayTraderDate)
+		}
+	}
+	return acc, err
+}
+
+type account struct {
+	AccountNumber     string          `json:"account_number"`
+	URL               string          `json:"url"`
+	Type              string          `json:"type"`
+	Deactivated       bool            `json:"deactivated"`
+	OptionLevel       string          `json:"option_level"`
+	BuyingPower       string          `json:"buying_power"`
+	Cash              string          `json:"cash"`
+	CashHeldForOrders string          `json:"cash_held_for_orders"`
+	UnsettledFunds    string          `json:"unsettled_funds"`
+	UnsettledDebit    string          `json:"unsettled_debit"`
+	UnclearedDeposits string          `json:"uncleared_deposits"`
+	SMA               string          `json:"sma"`
+	MarginBalances    *marginBalances `json:"margin_balances"`
+	CreatedAt         string          `json:"created_at"`
+	UpdatedAt         string          `json:"updated_at"`
+}
+
+type marginBalances struct {
+	Limit                      string `json:"margin_limit"`
+	UnallocatedCash            string `json:"unallocated_margin_cash"`
+	DayTradeBuyingPower        string `json:"day_trade_buying_power"`
+	OvernightBuyingPower       string `json:"overnight_buying_power"`
+	DayTradeRatio              string `json:"day_trade_ratio"`
+	OvernightRatio             string `json:"overnight_ratio"`
+	MarkedPatternDayTraderDate string `json:"marked_pattern_day_trader_date"`
+}
+
+// PortfolioSummary is the value of an account.
+type PortfolioSummary struct {
+	Account string // URL of the account.
+	// Equity is the total value of the account: cash plus the market value
+	// of all positions.
+	Equity      float64
+	MarketValue float64 // Of all positions.
+	// ExtendedHoursEquity and ExtendedHoursMarketValue are Equity and
+	// MarketValue at extended hours prices. Zero outside extended hours.
+	ExtendedHoursEquity      float64
+	ExtendedHoursMarketValue float64
+	// EquityPreviousClose is Equity at the previous close, adjusted for
+	// deposits and withdrawals since.
+	EquityPreviousClose    float64
+	WithdrawableAmount     float64
+	ExcessMargin           float64
+	ExcessMaintenance      float64
+	UnwithdrawableDeposits float64
+	StartDate              time.Time // When the account was opened.
+}
+
+// DayChange returns the change in equity since the previous close, in dollars
+// and as a percentage.
+func (p PortfolioSummary) DayChange() (float64, float64) {
+	equity := p.Equity
+	if p.ExtendedHoursEquity != 0 {
+		equity = p.ExtendedHoursEquity
+	}
+	change := equity - p.EquityPreviousClose
+	if p.EquityPreviousClose == 0 {
+		return change, 0
+	}
+	return change, change / p.EquityPreviousClose * 100
+}
+
+// PortfolioSummary returns the value of the client's account, AccountID.
+func (c *Client) PortfolioSummary() (PortfolioSummary, error) {
+	resp, err := c.get(portfoliosURI + c.AccountID + "/")
+	if err != nil {
+		return PortfolioSummary{}, err
+	}
+	var p portfolioSummary
+	err = json.Unmarshal(resp, &p)
+	if err != nil {
+		return PortfolioSummary{}, err
+	}
+	equity, err := parseOptionalFloat64(p.Equity, nil)
+	marketValue, err := parseOptionalFloat64(p.MarketValue, err)
+	extEquity, err := parseOptionalFloat64(p.ExtendedHoursEquity, err)
+	extMarketValue, err := parseOptionalFloat64(p.ExtendedHoursMarketValue, err)
+	prevClose, err := parseOptionalFloat64(p.AdjustedEquityPreviousClose, err)
+	withdrawable, err := parseOptionalFloat64(p.WithdrawableAmount, err)
+	excessMargin, err := parseOptionalFloat64(p.ExcessMargin, err)
+	excessMaintenance, err := parseOptionalFloat64(p.ExcessMaintenance, err)
+	unwithdrawable, err := parseOptionalFloat64(p.UnwithdrawableDeposits, err)
+	var start time.Time
+	if p.StartDate != "" && err == nil {
+		start, err = time.Parse(dateFormat, p.StartDate)
+	}
+	return PortfolioSummary{
+		Account:                  p.Account,
+		Equity:                   equity,
+		MarketValue:              marketValue,
+		ExtendedHoursEquity:      extEquity,
+		ExtendedHoursMarketValue: extMarketValue,
+		EquityPreviousClose:      prevClose,
+		WithdrawableAmount:       withdrawable,
+		ExcessMargin:             excessMargin,
+		ExcessMaintenance:        excessMaintenance,
+		UnwithdrawableDeposits:   unwithdrawable,
+		StartDate:                start,
+	}, err
+}
+
+type portfolioSummary struct {
+	Account                     string `json:"account"`
+	Equity                      string `json:"equity"`
+	MarketValue                 string `json:"market_value"`
+	ExtendedHoursEquity         string `json:"extended_hours_equity"`
+	ExtendedHoursMarketValue    string `json:"extended_hours_market_value"`
+	AdjustedEquityPreviousClose string `json:"adjusted_equity_previous_close"`
+	WithdrawableAmount          string `json:"withdrawable_amount"`
+	ExcessMargin                string `json:"excess_margin"`
+	ExcessMaintenance           string `json:"excess_maintenance"`
+	UnwithdrawableDeposits      string `json:"unwithdrawable_deposits"`
+	StartDate                   string `json:"start_date"`
+}
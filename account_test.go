@@ -0,0 +1,168 @@
+package robinhood
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	httpmock "gopkg.in/jarcoal/httpmock.v1"
+)
+
+const marginAccount = `{"deactivated":false,"updated_at":"2018-06-22T14:01:02.113526Z","margin_balances":{"day_trade_buying_power":"12000.0000","start_of_day_overnight_buying_power":"6000.0000","overnight_buying_power_held_for_orders":"0.0000","cash_held_for_orders":"0.0000","unallocated_margin_cash":"2500.5000","overnight_ratio":"0.50","day_trade_ratio":"0.25","marked_pattern_day_trader_date":"2018-05-01","margin_limit":"3000.0000","overnight_buying_power":"6000.0000","unsettled_debit":"0.0000","sma":"4000.0000"},"portfolio":"https://api.robinhood.com/accounts/5RY82436/portfolio/","cash_balances":null,"can_downgrade_to_cash":"https://api.robinhood.com/accounts/5RY82436/can_downgrade_to_cash/","withdrawal_halted":false,"cash_available_for_withdrawal":"2000.0000","type":"margin","sma":"4000.0000","sweep_enabled":false,"deposit_halted":false,"buying_power":"6000.0000","user":"https://api.robinhood.com/user/","max_ach_early_access_amount":"1000.00","option_level":"option_level_2","instant_eligibility":{"state":"ok"},"cash_held_for_orders":"150.0000","only_position_closing_trades":false,"url":"https://api.robinhood.com/accounts/5RY82436/","positions":"https://api.robinhood.com/accounts/5RY82436/positions/","created_at":"2016-05-10T18:09:45.000000Z","cash":"2500.5000","sma_held_for_orders":"0.0000","unsettled_debit":"25.0000","account_number":"5RY82436","uncleared_deposits":"500.0000","unsettled_funds":"100.0000"}`
+
+const cashAccount = `{"deactivated":true,"updated_at":"2018-01-02T10:00:00Z","margin_balances":null,"cash_balances":{"cash":"10.0000","buying_power":"10.0000"},"type":"cash","buying_power":"10.0000","option_level":null,"url":"https://api.robinhood.com/accounts/CASH0001/","created_at":"2017-01-02T10:00:00Z","cash":"10.0000","account_number":"CASH0001","cash_held_for_orders":"0.0000","unsettled_funds":"0.0000","unsettled_debit":"0.0000","uncleared_deposits":"0.0000","sma":null}`
+
+func TestAccounts(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", apiURL+accountsURI, httpmock.NewStringResponder(200, `{"previous":null,"results":[`+marginAccount+`,`+cashAccount+`],"next":null}`))
+	httpmock.RegisterResponder("GET", apiURL+accountsURI+"5RY82436/", httpmock.NewStringResponder(200, marginAccount))
+	httpmock.RegisterResponder("GET", apiURL+accountsURI+"5RY82436/recent_day_trades/", httpmock.NewStringResponder(200,
+		`{"equity_day_trades":[{"symbol":"AAPL"},{"symbol":"SPY"}],"option_day_trades":[{"chain_symbol":"SPY"}]}`))
+
+	c := Client{AccountID: "5RY82436", Token: "token"}
+	accs, err := c.GetAccounts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(accs) != 2 {
+		t.Fatalf("len(accs) = %d, want 2", len(accs))
+	}
+	want := Account{
+		AccountNumber:     "5RY82436",
+		URL:               "https://api.robinhood.com/accounts/5RY82436/",
+		Type:              "margin",
+		OptionLevel:       "option_level_2",
+		BuyingPower:       6000,
+		Cash:              2500.5,
+		CashHeldForOrders: 150,
+		UnsettledFunds:    100,
+		UnsettledDebit:    25,
+		UnclearedDeposits: 500,
+		SMA:               4000,
+		Margin: MarginBalances{
+			Limit:                      3000,
+			UnallocatedCash:            2500.5,
+			DayTradeBuyingPower:        12000,
+			OvernightBuyingPower:       6000,
+			DayTradeRatio:              0.25,
+			OvernightRatio:             0.5,
+			MarkedPatternDayTraderDate: time.Date(2018, 5, 1, 0, 0, 0, 0, time.UTC),
+		},
+		CreatedAt: time.Date(2016, 5, 10, 18, 9, 45, 0, time.UTC),
+		UpdatedAt: time.Date(2018, 6, 22, 14, 1, 2, 113526000, time.UTC),
+	}
+	if accs[0] != want {
+		t.Errorf("margin account = %+v, want %+v", accs[0], want)
+	}
+	if cash := accs[1]; cash.Type != "cash" || !cash.Deactivated || cash.Cash != 10 || cash.OptionLevel != "" || cash.Margin != (MarginBalances{}) {
+		t.Errorf("cash account = %+v", cash)
+	}
+
+	acc, err := c.Account()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if acc != want {
+		t.Errorf("Account() = %+v, want %+v", acc, want)
+	}
+
+	n, err := c.DayTradeCount()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 3 {
+		t.Errorf("DayTradeCount() = %d, want 3", n)
+	}
+}
+
+func TestPortfolioSummary(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", apiURL+portfoliosURI+"5RY82436/", httpmock.NewStringResponder(200,
+		`{"unwithdrawable_grants":"0.0000","account":"https://api.robinhood.com/accounts/5RY82436/","excess_maintenance_with_uncleared_deposits":"3000.0000","url":"https://api.robinhood.com/portfolios/5RY82436/","excess_maintenance":"2800.0000","market_value":"8000.0000","withdrawable_amount":"2000.0000","last_core_market_value":"7900.0000","unwithdrawable_deposits":"500.0000","extended_hours_equity":null,"excess_margin":"4000.0000","excess_margin_with_uncleared_deposits":"4500.0000","equity":"10500.0000","last_core_equity":"10400.0000","adjusted_equity_previous_close":"10000.0000","equity_previous_close":"10000.0000","start_date":"2016-05-10","extended_hours_market_value":null}`))
+
+	c := Client{AccountID: "5RY82436", Token: "token"}
+	p, err := c.PortfolioSummary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := PortfolioSummary{
+		Account:                "https://api.robinhood.com/accounts/5RY82436/",
+		Equity:                 10500,
+		MarketValue:            8000,
+		EquityPreviousClose:    10000,
+		WithdrawableAmount:     2000,
+		ExcessMargin:           4000,
+		ExcessMaintenance:      2800,
+		UnwithdrawableDeposits: 500,
+		StartDate:              time.Date(2016, 5, 10, 0, 0, 0, 0, time.UTC),
+	}
+	if p != want {
+		t.Errorf("PortfolioSummary() = %+v, want %+v", p, want)
+	}
+	if change, pct := p.DayChange(); change != 500 || pct != 5 {
+		t.Errorf("DayChange() = %v, %v; want 500, 5", change, pct)
+	}
+}
+
+func TestAccountJSON(t *testing.T) {
+	b, err := json.Marshal(Account{AccountNumber: "5RY82436", URL: "https://api.robinhood.com/accounts/5RY82436/", BuyingPower: 6000})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{`"account_number":"5RY82436"`, `"url":"https://api.robinhood.com/accounts/5RY82436/"`, `"buying_power":6000`} {
+		if !strings.Contains(string(b), want) {
+			t.Errorf("JSON %s is missing %s", b, want)
+		}
+	}
+}
+
+func TestAccountsUnparsed(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	broken := `{"account_number":"BROKEN01","url":"https://api.robinhood.com/accounts/BROKEN01/","type":"margin","cash":"1.0000","margin_balances":{"margin_limit":1000}}`
+	httpmock.RegisterResponder("GET", apiURL+accountsURI, httpmock.NewStringResponder(200, `{"previous":null,"results":[`+marginAccount+`,`+broken+`,`+cashAccount+`],"next":null}`))
+
+	c := Client{AccountID: "BROKEN01", Token: "token"}
+	accs, err := c.GetAccounts()
+	unparsed, ok := err.(*UnparsedAccountsError)
+	if !ok || len(unparsed.Accounts) != 1 || unparsed.Accounts[0] != "BROKEN01" {
+		t.Fatalf("err = %v, want BROKEN01 unparsed", err)
+	}
+	if len(accs) != 2 || accs[0].AccountNumber != "5RY82436" || accs[1].AccountNumber != "CASH0001" {
+		t.Errorf("accounts = %+v", accs)
+	}
+
+	// Orders only need the URL of the account.
+	u, err := c.accountURL()
+	if err != nil || u != "https://api.robinhood.com/accounts/BROKEN01/" {
+		t.Errorf("accountURL = %q, %v", u, err)
+	}
+}
+
+func TestAccountUnmarshalJSON(t *testing.T) {
+	var acc Account
+	if err := json.Unmarshal([]byte(marginAccount), &acc); err != nil {
+		t.Fatal(err)
+	}
+	if acc.AccountNumber != "5RY82436" || acc.Cash != 2500.5 || acc.Margin.Limit != 3000 || acc.CreatedAt.Year() != 2016 {
+		t.Errorf("account = %+v", acc)
+	}
+}
+
+func TestAccountID(t *testing.T) {
+	for u, want := range map[string]string{
+		"https://api.robinhood.com/accounts/5RY82436/": "5RY82436",
+		"https://api.robinhood.com/accounts/5RY82436":  "5RY82436",
+		"": "",
+	} {
+		if got := AccountID(u); got != want {
+			t.Errorf("AccountID(%q) = %q, want %q", u, got, want)
+		}
+	}
+}
@@ -0,0 +1,294 @@
+package robinhood
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// This file deals with bank accounts and transfers of money to and from them.
+
+// BankAccount is a bank account linked to the user's accounts for ACH
+// transfers.
+type BankAccount struct {
+	ID            string
+	URL           string
+	Account       string // URL of the brokerage account.
+	HolderName    string
+	Type          string // "checking" or "savings".
+	Nickname      string
+	AccountNumber string // Usually only the last four digits.
+	RoutingNumber string
+	Verified      bool
+	State         string // "approved", "pending", "unlinked", etc.
+	CreatedAt     time.Time
+	VerifiedAt    time.Time // Zero if not verified.
+	UnlinkedAt    time.Time // Zero if still linked.
+}
+
+// BankAccounts returns the bank accounts linked, now or in the past, to the
+// user's accounts.
+func (c *Client) BankAccounts() ([]BankAccount, error) {
+	resp, err := c.paginatedGet(achRelationsURI)
+	if err != nil {
+		return nil, err
+	}
+	var rels []achRelationship
+	err = json.Unmarshal(resp, &rels)
+	if err != nil {
+		return nil, err
+	}
+	var banks []BankAccount
+	for _, r := range rels {
+		created, err := parseTime(r.CreatedAt, nil)
+		var verified, unlinked time.Time
+		if r.VerifiedAt != "" {
+			verified, err = parseTime(r.VerifiedAt, err)
+		}
+		if r.UnlinkedAt != "" {
+			unlinked, err = parseTime(r.UnlinkedAt, err)
+		}
+		if err != nil {
+			return nil, err
+		}
+		banks = append(banks, BankAccount{
+			ID:            r.ID,
+			URL:           r.URL,
+			Account:       r.Account,
+			HolderName:    r.HolderName,
+			Type:          r.Type,
+			Nickname:      r.Nickname,
+			AccountNumber: r.AccountNumber,
+			RoutingNumber: r.RoutingNumber,
+			Verified:      r.Verified,
+			State:         r.State,
+			CreatedAt:     created,
+			VerifiedAt:    verified,
+			UnlinkedAt:    unlinked,
+		})
+	}
+	return banks, nil
+}
+
+type achRelationship struct {
+	ID            string `json:"id"`
+	URL           string `json:"url"`
+	Account       string `json:"account"`
+	HolderName    string `json:"bank_account_holder_name"`
+	Type          string `json:"bank_account_type"`
+	Nickname      string `json:"bank_account_nickname"`
+	AccountNumber string `json:"bank_account_number"`
+	RoutingNumber string `json:"bank_routing_number"`
+	Verified      bool   `json:"verified"`
+	State         string `json:"state"`
+	CreatedAt     string `json:"created_at"`
+	VerifiedAt    string `json:"verified_at"`
+	UnlinkedAt    string `json:"unlinked_at"`
+}
+
+// TransferDirection is whether money moves into or out of a brokerage
+// account.
+type TransferDirection int
+
+// Directions of a transfer.
+const (
+	Deposit TransferDirection = iota + 1
+	Withdrawal
+)
+
+// String returns the direction as the API names it: "deposit" or "withdraw".
+func (d TransferDirection) String() string {
+	switch d {
+	case Deposit:
+		return "deposit"
+	case Withdrawal:
+		return "withdraw"
+	default:
+		return "invalid direction"
+	}
+}
+
+func parseTransferDirection(s string) (TransferDirection, error) {
+	switch s {
+	case "deposit":
+		return Deposit, nil
+	case "withdraw":
+		return Withdrawal, nil
+	default:
+		return 0, fmt.Errorf("unknown transfer direction %q", s)
+	}
+}
+
+// Transfer is a transfer of money between a bank account and a brokerage
+// account.
+type Transfer struct {
+	ID          string
+	URL         string
+	Account     string // URL of the account.
+	BankAccount string // URL of the bank account.
+	Direction   TransferDirection
+	Amount      float64
+	Fees        float64
+	// EarlyAccessAmount is the part of a deposit available before it
+	// settles.
+	EarlyAccessAmount float64
+	// State is "pending", "completed", "cancelled", "failed", "reversed",
+	// etc.
+	State             string
+	StatusDescription string
+	Scheduled         bool
+	// ExpectedLandingDate is when the money is expected to arrive. Zero if
+	// unknown.
+	ExpectedLandingDate time.Time
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+
+	cancel string // URL to cancel the transfer, if it can still be.
+}
+
+// Cancelable returns whether the transfer can still be cancelled.
+func (t Transfer) Cancelable() bool {
+	return t.cancel != ""
+}
+
+// Transfers returns all transfers between the user's bank and brokerage
+// accounts, most recent first.
+func (c *Client) Transfers() ([]Transfer, error) {
+	resp, err := c.paginatedGet(achTransfersURI)
+	if err != nil {
+		return nil, err
+	}
+	var ts []achTransfer
+	err = json.Unmarshal(resp, &ts)
+	if err != nil {
+		return nil, err
+	}
+	var transfers []Transfer
+	for _, t := range ts {
+		transfer, err := newTransfer(t)
+		if err != nil {
+			return nil, err
+		}
+		transfers = append(transfers, transfer)
+	}
+	return transfers, nil
+}
+
+// Transfer starts a transfer of amount dollars between bank, one of
+// BankAccounts, and the brokerage account it is linked to. It fails unless
+// the client's AllowTransfers is true.
+func (c *Client) Transfer(bank BankAccount, direction TransferDirection, amount float64) (Transfer, error) {
+	if !c.AllowTransfers {
+		return Transfer{}, fmt.Errorf("transfers are not allowed; set Client.AllowTransfers")
+	}
+	if direction != Deposit && direction != Withdrawal {
+		return Transfer{}, fmt.Errorf("invalid transfer direction %d", int(direction))
+	}
+	if amount <= 0 {
+		return Transfer{}, fmt.Errorf("transfer amount must be positive, got %v", amount)
+	}
+	if bank.URL == "" {
+		return Transfer{}, fmt.Errorf("bank account has no URL; use one returned by BankAccounts")
+	}
+	refID, err := newRefID()
+	if err != nil {
+		return Transfer{}, err
+	}
+	form := url.Values{}
+	form.Add("ach_relationship", bank.URL)
+	form.Add("amount", fmt.Sprintf("%.2f", amount))
+	form.Add("direction", direction.String())
+	form.Add("ref_id", refID)
+	resp, err := c.post(achTransfersURI, form.Encode())
+	if err != nil {
+		return Transfer{}, err
+	}
+	var t achTransfer
+	err = json.Unmarshal(resp, &t)
+	if err != nil {
+		return Transfer{}, err
+	}
+	return newTransfer(t)
+}
+
+// CancelTransfer cancels a pending transfer. It fails unless the client's
+// AllowTransfers is true.
+func (c *Client) CancelTransfer(t Transfer) error {
+	if !c.AllowTransfers {
+		return fmt.Errorf("transfers are not allowed; set Client.AllowTransfers")
+	}
+	if !t.Cancelable() {
+		return fmt.Errorf("transfer %s can't be cancelled", t.ID)
+	}
+	req, err := http.NewRequest("POST", t.cancel, nil)
+	if err != nil {
+		return err
+	}
+	_, err = c.doReqWithAuth(req)
+	return err
+}
+
+// newRefID returns a random UUID, which identifies a request so that the
+// server doesn't process it twice.
+func newRefID() (string, error) {
+	var b [16]byte
+	_, err := rand.Read(b[:])
+	if err != nil {
+		return "", err
+	}
+	b[6] = b[6]&0x0f | 0x40 // Version 4.
+	b[8] = b[8]&0x3f | 0x80 // RFC 4122 variant.
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:]), nil
+}
+
+// newTransfer converts t to the external format.
+func newTransfer(t achTransfer) (Transfer, error) {
+	direction, err := parseTransferDirection(t.Direction)
+	amount, err := parseFloat64(t.Amount, err)
+	fees, err := parseOptionalFloat64(t.Fees, err)
+	early, err := parseOptionalFloat64(t.EarlyAccessAmount, err)
+	created, err := parseTime(t.CreatedAt, err)
+	updated, err := parseTime(t.UpdatedAt, err)
+	var landing time.Time
+	if t.ExpectedLandingDate != "" && err == nil {
+		landing, err = time.Parse(dateFormat, t.ExpectedLandingDate)
+	}
+	return Transfer{
+		ID:                  t.ID,
+		URL:                 t.URL,
+		Account:             t.Account,
+		BankAccount:         t.ACHRelationship,
+		Direction:           direction,
+		Amount:              amount,
+		Fees:                fees,
+		EarlyAccessAmount:   early,
+		State:               t.State,
+		StatusDescription:   t.StatusDescription,
+		Scheduled:           t.Scheduled,
+		ExpectedLandingDate: landing,
+		CreatedAt:           created,
+		UpdatedAt:           updated,
+		cancel:              t.Cancel,
+	}, err
+}
+
+type achTransfer struct {
+	ID                  string `json:"id"`
+	URL                 string `json:"url"`
+	Cancel              string `json:"cancel"`
+	Account             string `json:"account"`
+	ACHRelationship     string `json:"ach_relationship"`
+	Direction           string `json:"direction"`
+	Amount              string `json:"amount"`
+	Fees                string `json:"fees"`
+	EarlyAccessAmount   string `json:"early_access_amount"`
+	State               string `json:"state"`
+	StatusDescription   string `json:"status_description"`
+	Scheduled           bool   `json:"scheduled"`
+	ExpectedLandingDate string `json:"expected_landing_date"`
+	CreatedAt           string `json:"created_at"`
+	UpdatedAt           string `json:"updated_at"`
+}
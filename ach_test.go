@@ -0,0 +1,139 @@
+package robinhood
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	httpmock "gopkg.in/jarcoal/httpmock.v1"
+)
+
+const transfersResponse = `{"previous":null,"results":[` +
+	`{"id":"t1","ref_id":"r1","url":"https://api.robinhood.com/ach/transfers/t1/","cancel":"https://api.robinhood.com/ach/transfers/t1/cancel/","ach_relationship":"https://api.robinhood.com/ach/relationships/b1/","account":"https://api.robinhood.com/accounts/5RY82436/","amount":"500.00","fees":"0.00","direction":"deposit","state":"pending","status_description":"","scheduled":false,"expected_landing_date":"2018-06-21","early_access_amount":"500.00","created_at":"2018-06-18T14:00:00.000000Z","updated_at":"2018-06-18T14:00:01.000000Z"},` +
+	`{"id":"t0","ref_id":"r0","url":"https://api.robinhood.com/ach/transfers/t0/","cancel":null,"ach_relationship":"https://api.robinhood.com/ach/relationships/b1/","account":"https://api.robinhood.com/accounts/5RY82436/","amount":"120.50","fees":"0.00","direction":"withdraw","state":"completed","status_description":"","scheduled":false,"expected_landing_date":null,"early_access_amount":"0.00","created_at":"2018-05-01T14:00:00.000000Z","updated_at":"2018-05-03T14:00:00.000000Z"}` +
+	`],"next":null}`
+
+func TestBankAccounts(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", apiURL+achRelationsURI, httpmock.NewStringResponder(200,
+		`{"previous":null,"results":[{"id":"b1","url":"https://api.robinhood.com/ach/relationships/b1/","account":"https://api.robinhood.com/accounts/5RY82436/","bank_account_holder_name":"Jane Doe","bank_account_type":"checking","bank_account_nickname":"Everyday","bank_account_number":"1234","bank_routing_number":"021000021","verified":true,"state":"approved","created_at":"2017-01-02T15:04:05.000000Z","verified_at":"2017-01-03T15:04:05.000000Z","unlinked_at":null}],"next":null}`))
+
+	c := Client{Token: "token"}
+	banks, err := c.BankAccounts()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := BankAccount{
+		ID:            "b1",
+		URL:           "https://api.robinhood.com/ach/relationships/b1/",
+		Account:       "https://api.robinhood.com/accounts/5RY82436/",
+		HolderName:    "Jane Doe",
+		Type:          "checking",
+		Nickname:      "Everyday",
+		AccountNumber: "1234",
+		RoutingNumber: "021000021",
+		Verified:      true,
+		State:         "approved",
+		CreatedAt:     time.Date(2017, 1, 2, 15, 4, 5, 0, time.UTC),
+		VerifiedAt:    time.Date(2017, 1, 3, 15, 4, 5, 0, time.UTC),
+	}
+	if len(banks) != 1 || banks[0] != want {
+		t.Errorf("BankAccounts = %+v, want [%+v]", banks, want)
+	}
+}
+
+func TestTransfers(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", apiURL+achTransfersURI, httpmock.NewStringResponder(200, transfersResponse))
+
+	c := Client{Token: "token"}
+	transfers, err := c.Transfers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(transfers) != 2 {
+		t.Fatalf("got %d transfers, want 2", len(transfers))
+	}
+	dep, wd := transfers[0], transfers[1]
+	if dep.Direction != Deposit || dep.Amount != 500 || dep.EarlyAccessAmount != 500 || dep.State != "pending" || dep.Account != "https://api.robinhood.com/accounts/5RY82436/" {
+		t.Errorf("deposit = %+v", dep)
+	}
+	if !dep.ExpectedLandingDate.Equal(time.Date(2018, 6, 21, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("ExpectedLandingDate = %v", dep.ExpectedLandingDate)
+	}
+	if !dep.Cancelable() {
+		t.Error("pending deposit isn't cancelable")
+	}
+	if wd.Direction != Withdrawal || wd.Amount != 120.5 || wd.State != "completed" || !wd.ExpectedLandingDate.IsZero() {
+		t.Errorf("withdrawal = %+v", wd)
+	}
+	if wd.Cancelable() {
+		t.Error("completed withdrawal is cancelable")
+	}
+}
+
+func TestTransferNotAllowed(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	c := Client{Token: "token"}
+	bank := BankAccount{URL: "https://api.robinhood.com/ach/relationships/b1/"}
+	if _, err := c.Transfer(bank, Deposit, 100); err == nil {
+		t.Error("Transfer succeeded without AllowTransfers")
+	}
+	if err := c.CancelTransfer(Transfer{ID: "t1", cancel: apiURL + achTransfersURI + "t1/cancel/"}); err == nil {
+		t.Error("CancelTransfer succeeded without AllowTransfers")
+	}
+}
+
+func TestTransfer(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	var form map[string][]string
+	httpmock.RegisterResponder("POST", apiURL+achTransfersURI, func(req *http.Request) (*http.Response, error) {
+		if err := req.ParseForm(); err != nil {
+			return nil, err
+		}
+		form = req.PostForm
+		return httpmock.NewStringResponse(200, `{"id":"t2","url":"https://api.robinhood.com/ach/transfers/t2/","cancel":"https://api.robinhood.com/ach/transfers/t2/cancel/","ach_relationship":"https://api.robinhood.com/ach/relationships/b1/","amount":"250.00","fees":"0.00","direction":"withdraw","state":"pending","scheduled":false,"expected_landing_date":null,"early_access_amount":"0.00","created_at":"2018-06-18T14:00:00.000000Z","updated_at":"2018-06-18T14:00:00.000000Z"}`), nil
+	})
+	cancelled := false
+	httpmock.RegisterResponder("POST", apiURL+achTransfersURI+"t2/cancel/", func(req *http.Request) (*http.Response, error) {
+		cancelled = true
+		return httpmock.NewStringResponse(200, `{}`), nil
+	})
+
+	c := Client{Token: "token", AllowTransfers: true}
+	bank := BankAccount{URL: "https://api.robinhood.com/ach/relationships/b1/"}
+	tr, err := c.Transfer(bank, Withdrawal, 250)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Join(form["amount"], ","); got != "250.00" {
+		t.Errorf("amount = %q, want 250.00", got)
+	}
+	if got := strings.Join(form["direction"], ","); got != "withdraw" {
+		t.Errorf("direction = %q, want withdraw", got)
+	}
+	if got := strings.Join(form["ach_relationship"], ","); got != bank.URL {
+		t.Errorf("ach_relationship = %q, want %q", got, bank.URL)
+	}
+	if len(form["ref_id"]) != 1 || len(form["ref_id"][0]) != 36 {
+		t.Errorf("ref_id = %q, want a UUID", form["ref_id"])
+	}
+	if tr.ID != "t2" || tr.Direction != Withdrawal || tr.Amount != 250 {
+		t.Errorf("transfer = %+v", tr)
+	}
+	if err := c.CancelTransfer(tr); err != nil {
+		t.Fatal(err)
+	}
+	if !cancelled {
+		t.Error("transfer wasn't cancelled")
+	}
+}
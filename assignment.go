@@ -0,0 +1,200 @@
+package robinhood
+
+import (
+	"math"
+	"sort"
+	"time"
+)
+
+// This file deals with the risk of assignment of expiring option positions.
+
+// AssignmentRisk is how likely a short option is to be assigned before or at
+// expiration.
+type AssignmentRisk int
+
+// Levels of AssignmentRisk, from lowest to highest.
+const (
+	// NoAssignmentRisk is the risk of long positions, which can't be
+	// assigned.
+	NoAssignmentRisk AssignmentRisk = iota
+	LowAssignmentRisk
+	ModerateAssignmentRisk
+	HighAssignmentRisk
+)
+
+// String returns "none", "low", "moderate" or "high".
+func (r AssignmentRisk) String() string {
+	switch r {
+	case NoAssignmentRisk:
+		return "none"
+	case LowAssignmentRisk:
+		return "low"
+	case ModerateAssignmentRisk:
+		return "moderate"
+	case HighAssignmentRisk:
+		return "high"
+	default:
+		return "invalid assignment risk"
+	}
+}
+
+const (
+	// minExtrinsic is the time value per share below which an in-the-money
+	// option is likely to be exercised early.
+	minExtrinsic = 0.05
+	// pinRange is how close, as a fraction of the strike, the underlying
+	// must be to the strike at expiration for assignment to be uncertain.
+	pinRange = 0.01
+)
+
+// ExDividend is an upcoming dividend of a stock.
+type ExDividend struct {
+	Date   time.Time // Ex-dividend date.
+	Amount float64   // Per share.
+}
+
+// ExpiringOption is an option position that expires soon.
+type ExpiringOption struct {
+	Position         OptionPosition
+	DaysToExpiration int
+	Spot             float64 // Price of the underlying.
+	// Moneyness is how far in the money the option is, as a fraction of its
+	// strike. It is negative if the option is out of the money.
+	Moneyness float64
+	Intrinsic float64 // Per share.
+	// Extrinsic is the time value left per share: the market price of the
+	// option less its intrinsic value. Zero if there is no market data.
+	Extrinsic float64
+	// ExDividend is the dividend of the underlying going ex before
+	// expiration, if any. Holders of in-the-money calls may exercise early to
+	// collect it.
+	ExDividend *ExDividend
+	Risk       AssignmentRisk
+}
+
+// InTheMoney returns whether the option would be exercised at the current
+// price of the underlying.
+func (e ExpiringOption) InTheMoney() bool {
+	return e.Intrinsic > 0
+}
+
+// AssignmentReport returns the option positions expiring within days of now,
+// ordered by expiration and highest risk first. Dividends are the upcoming
+// ex-dividend dates of the underlying symbols, if known; symbols without one
+// are assumed to pay no dividend before expiration.
+//
+// Positions whose underlying has no quote are left out of the report, which
+// is returned along with an *UnknownSymbolsError listing those underlyings.
+// Otherwise, as with OptionPositions, a *MissingMarketDataError is returned
+// along with the report if some positions have no market data.
+func (c *Client) AssignmentReport(days int, now time.Time, dividends map[string]ExDividend) ([]ExpiringOption, error) {
+	positions, err := c.OptionPositions()
+	if _, ok := err.(*MissingMarketDataError); err != nil && !ok {
+		return nil, err
+	}
+	missingErr := err
+
+	var expiring []OptionPosition
+	var symbols []string
+	seen := make(map[string]bool)
+	for _, p := range positions {
+		if d := daysBetween(now, p.Chain.Expiration); d < 0 || d > float64(days) {
+			continue
+		}
+		expiring = append(expiring, p)
+		if !seen[p.Chain.Symbol] {
+			seen[p.Chain.Symbol] = true
+			symbols = append(symbols, p.Chain.Symbol)
+		}
+	}
+	if len(expiring) == 0 {
+		return nil, missingErr
+	}
+	quotes, err := c.Quote(symbols)
+	if _, ok := err.(*UnknownSymbolsError); err != nil && !ok {
+		return nil, err
+	}
+	spots := make(map[string]float64)
+	for _, q := range quotes {
+		if price := q.Price(); price > 0 {
+			spots[q.Symbol] = price
+		}
+	}
+	var unknown *UnknownSymbolsError
+	for _, s := range symbols {
+		if spots[s] == 0 {
+			if unknown == nil {
+				unknown = &UnknownSymbolsError{}
+			}
+			unknown.Symbols = append(unknown.Symbols, s)
+		}
+	}
+
+	var report []ExpiringOption
+	for _, p := range expiring {
+		if spots[p.Chain.Symbol] == 0 {
+			// Without a spot price, calls would look out of the money
+			// and puts in the money.
+			continue
+		}
+		e := ExpiringOption{
+			Position:         p,
+			DaysToExpiration: int(daysBetween(now, p.Chain.Expiration)),
+			Spot:             spots[p.Chain.Symbol],
+		}
+		if p.Chain.Type == Put {
+			e.Intrinsic = math.Max(p.Chain.Strike-e.Spot, 0)
+			e.Moneyness = (p.Chain.Strike - e.Spot) / p.Chain.Strike
+		} else {
+			e.Intrinsic = math.Max(e.Spot-p.Chain.Strike, 0)
+			e.Moneyness = (e.Spot - p.Chain.Strike) / p.Chain.Strike
+		}
+		if p.Option.MarketPrice > 0 {
+			e.Extrinsic = math.Max(p.Option.MarketPrice-e.Intrinsic, 0)
+		}
+		if div, ok := dividends[p.Chain.Symbol]; ok {
+			d := truncateDate(div.Date)
+			if !d.Before(truncateDate(now)) && !d.After(truncateDate(p.Chain.Expiration)) {
+				e.ExDividend = &div
+			}
+		}
+		e.Risk = e.assignmentRisk()
+		report = append(report, e)
+	}
+	sort.SliceStable(report, func(i, j int) bool {
+		ei, ej := report[i].Position.Chain.Expiration, report[j].Position.Chain.Expiration
+		if !ei.Equal(ej) {
+			return ei.Before(ej)
+		}
+		return report[i].Risk > report[j].Risk
+	})
+	if unknown != nil {
+		return report, unknown
+	}
+	return report, missingErr
+}
+
+// assignmentRisk estimates the risk of assignment of e.
+func (e ExpiringOption) assignmentRisk() AssignmentRisk {
+	if e.Position.Direction != Short {
+		return NoAssignmentRisk
+	}
+	if !e.InTheMoney() {
+		if e.DaysToExpiration == 0 && -e.Moneyness < pinRange {
+			return ModerateAssignmentRisk
+		}
+		return LowAssignmentRisk
+	}
+	switch {
+	case e.DaysToExpiration == 0:
+		return HighAssignmentRisk
+	case e.Position.Chain.Type == Call && e.ExDividend != nil && e.Extrinsic < e.ExDividend.Amount:
+		// Exercising before the ex-dividend date is worth more than the
+		// time value given up.
+		return HighAssignmentRisk
+	case e.Position.Option.MarketPrice > 0 && e.Extrinsic < minExtrinsic:
+		return HighAssignmentRisk
+	default:
+		return ModerateAssignmentRisk
+	}
+}
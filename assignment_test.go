@@ -0,0 +1,99 @@
+package robinhood
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	httpmock "gopkg.in/jarcoal/httpmock.v1"
+)
+
+func TestAssignmentReport(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	for url, reply := range optionPositions {
+		method := "GET"
+		if strings.Contains(url, oAuthUpgradeURI) {
+			method = "POST"
+		}
+		httpmock.RegisterResponder(method, url, httpmock.NewStringResponder(200, reply))
+	}
+	httpmock.RegisterResponder("GET", apiURL+quotesURI+"?symbols=SPY", httpmock.NewStringResponder(200,
+		`{"results":[{"ask_price":"297.1000","bid_price":"296.9000","last_trade_price":"297.0000","symbol":"SPY"}]}`))
+
+	c := Client{AccountID: "account", Token: "token"}
+	now := time.Date(2018, 6, 27, 15, 0, 0, 0, time.UTC)
+	report, err := c.AssignmentReport(1, now, nil)
+	if _, ok := err.(*MissingMarketDataError); !ok || len(report) != 0 {
+		t.Fatalf("AssignmentReport(1) = %v, %v; want nothing expiring", report, err)
+	}
+
+	div := ExDividend{Date: time.Date(2018, 6, 28, 0, 0, 0, 0, time.UTC), Amount: 1.4}
+	report, err = c.AssignmentReport(7, now, map[string]ExDividend{"SPY": div})
+	if _, ok := err.(*MissingMarketDataError); !ok {
+		t.Fatalf("err = %v, want missing market data", err)
+	}
+	if len(report) != 2 {
+		t.Fatalf("len(report) = %d, want 2", len(report))
+	}
+
+	// The short put, in the money, comes first.
+	put := report[0]
+	if put.Position.Chain.Strike != 298 || put.Position.Direction != Short || put.DaysToExpiration != 2 || put.Spot != 297 {
+		t.Errorf("put = %+v", put)
+	}
+	if !put.InTheMoney() || put.Intrinsic != 1 || put.Extrinsic != 0 || put.Risk != ModerateAssignmentRisk {
+		t.Errorf("put intrinsic %v, extrinsic %v, risk %v", put.Intrinsic, put.Extrinsic, put.Risk)
+	}
+	if put.ExDividend == nil || !put.ExDividend.Date.Equal(div.Date) {
+		t.Errorf("put ex-dividend = %v, want %v", put.ExDividend, div)
+	}
+
+	call := report[1]
+	if call.Position.Chain.Strike != 296 || call.Intrinsic != 1 || call.Extrinsic != 0.25 || call.Risk != NoAssignmentRisk {
+		t.Errorf("call = %+v", call)
+	}
+	if m := call.Moneyness; m < 0.0033 || m > 0.0034 {
+		t.Errorf("call moneyness = %v, want 1/296", m)
+	}
+
+	// Without a quote of the underlying, its positions are left out.
+	httpmock.RegisterResponder("GET", apiURL+quotesURI+"?symbols=SPY", httpmock.NewStringResponder(200, `{"results":[null]}`))
+	report, err = c.AssignmentReport(7, now, nil)
+	unknown, ok := err.(*UnknownSymbolsError)
+	if !ok || len(unknown.Symbols) != 1 || unknown.Symbols[0] != "SPY" {
+		t.Fatalf("err = %v, want SPY unknown", err)
+	}
+	if len(report) != 0 {
+		t.Errorf("report = %+v, want nothing", report)
+	}
+}
+
+func TestAssignmentRisk(t *testing.T) {
+	short := func(typ OptionType, marketPrice float64) OptionPosition {
+		return OptionPosition{
+			Chain:     Chain{Symbol: "SPY", Strike: 100, Type: typ},
+			Direction: Short,
+			Option:    Option{MarketPrice: marketPrice},
+		}
+	}
+	div := &ExDividend{Amount: 0.5}
+	tests := []struct {
+		e    ExpiringOption
+		want AssignmentRisk
+	}{
+		{ExpiringOption{Position: short(Call, 1), DaysToExpiration: 5, Moneyness: -0.05}, LowAssignmentRisk},
+		{ExpiringOption{Position: short(Call, 0.1), DaysToExpiration: 0, Moneyness: -0.005}, ModerateAssignmentRisk},
+		{ExpiringOption{Position: short(Call, 3), DaysToExpiration: 5, Intrinsic: 2, Extrinsic: 1}, ModerateAssignmentRisk},
+		{ExpiringOption{Position: short(Call, 2.3), DaysToExpiration: 5, Intrinsic: 2, Extrinsic: 0.3, ExDividend: div}, HighAssignmentRisk},
+		{ExpiringOption{Position: short(Put, 10.02), DaysToExpiration: 5, Intrinsic: 10, Extrinsic: 0.02}, HighAssignmentRisk},
+		{ExpiringOption{Position: short(Put, 3), DaysToExpiration: 0, Intrinsic: 2, Extrinsic: 1}, HighAssignmentRisk},
+		{ExpiringOption{Position: OptionPosition{Direction: Long}, Intrinsic: 2}, NoAssignmentRisk},
+	}
+	for i, test := range tests {
+		if got := test.e.assignmentRisk(); got != test.want {
+			t.Errorf("%d: risk = %v, want %v", i, got, test.want)
+		}
+	}
+}
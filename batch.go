@@ -0,0 +1,109 @@
+package robinhood
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// Deals with splitting requests for many items into several smaller requests.
+
+// maxSymbolsPerRequest is the maximum number of symbols sent in a single
+// ?symbols= request. Longer lists are rejected by the server or exceed the
+// maximum URL length.
+const maxSymbolsPerRequest = 75
+
+// forEachBatch splits n items into batches of at most size items and calls fn
+// concurrently for each batch with the half-open range [start, end) of items
+// in it. The number of requests actually in flight is bounded by the client's
+// MaxConcurrentRequests. It returns the first error encountered, if any.
+func forEachBatch(n, size int, fn func(start, end int) error) error {
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var firstErr error
+	for start := 0; start < n; start += size {
+		end := start + size
+		if end > n {
+			end = n
+		}
+		wg.Add(1)
+		go func(start, end int) {
+			defer wg.Done()
+			err := fn(start, end)
+			if err != nil {
+				mu.Lock()
+				if firstErr == nil {
+					firstErr = err
+				}
+				mu.Unlock()
+			}
+		}(start, end)
+	}
+	wg.Wait()
+	return firstErr
+}
+
+// forEach calls fn for each of n items, with i from 0 to n-1, from a pool of
+// as many goroutines as the client's MaxConcurrentRequests, rather than one
+// goroutine per item. Every item is processed even if some fail. It returns
+// the first error encountered, if any.
+func (c *Client) forEach(n int, fn func(i int) error) error {
+	c.init()
+	workers := cap(c.inFlight)
+	if workers > n {
+		workers = n
+	}
+	items := make(chan int)
+	go func() {
+		for i := 0; i < n; i++ {
+			items <- i
+		}
+		close(items)
+	}()
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var firstErr error
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range items {
+				err := fn(i)
+				if err != nil {
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = err
+					}
+					mu.Unlock()
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	return firstErr
+}
+
+// UnknownSymbolsError is returned, along with the results for all other
+// symbols, by calls such as Quote when some of the requested symbols are not
+// known to the server.
+type UnknownSymbolsError struct {
+	Symbols []string
+}
+
+// Error implements error.
+func (e *UnknownSymbolsError) Error() string {
+	return fmt.Sprintf("unknown symbols: %s", strings.Join(e.Symbols, ", "))
+}
+
+// unknownSymbols returns an *UnknownSymbolsError for the symbols at the given
+// indexes, or nil if there are none.
+func unknownSymbols(symbol []string, missing []int) error {
+	if len(missing) == 0 {
+		return nil
+	}
+	e := &UnknownSymbolsError{}
+	for _, i := range missing {
+		e.Symbols = append(e.Symbols, symbol[i])
+	}
+	return e
+}
@@ -0,0 +1,39 @@
+package robinhood
+
+import (
+	"sync"
+	"testing"
+)
+
+func TestForEach(t *testing.T) {
+	c := Client{MaxConcurrentRequests: 3}
+	var mu sync.Mutex
+	running, maxRunning := 0, 0
+	seen := make([]bool, 100)
+	err := c.forEach(len(seen), func(i int) error {
+		mu.Lock()
+		running++
+		if running > maxRunning {
+			maxRunning = running
+		}
+		seen[i] = true
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			running--
+			mu.Unlock()
+		}()
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if maxRunning > 3 {
+		t.Errorf("%d items processed concurrently, want at most 3", maxRunning)
+	}
+	for i, ok := range seen {
+		if !ok {
+			t.Errorf("item %d not processed", i)
+		}
+	}
+}
@@ -0,0 +1,58 @@
+package robinhood
+
+import (
+	"sync"
+	"time"
+)
+
+// DefaultCacheTTL is how long reference data, such as instruments, is cached
+// when Client.CacheTTL is zero.
+const DefaultCacheTTL = time.Hour
+
+// ttlCache is a map safe for concurrent use whose entries expire after a fixed
+// time to live.
+type ttlCache struct {
+	mu      sync.Mutex
+	ttl     time.Duration
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	value   interface{}
+	expires time.Time
+}
+
+func newTTLCache(ttl time.Duration) *ttlCache {
+	if ttl <= 0 {
+		ttl = DefaultCacheTTL
+	}
+	return &ttlCache{
+		ttl:     ttl,
+		entries: make(map[string]cacheEntry),
+	}
+}
+
+// get returns the value stored under key, if present and not yet expired.
+func (tc *ttlCache) get(key string) (interface{}, bool) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	e, ok := tc.entries[key]
+	if !ok {
+		return nil, false
+	}
+	if time.Now().After(e.expires) {
+		delete(tc.entries, key)
+		return nil, false
+	}
+	return e.value, true
+}
+
+// set stores value under key, replacing any previous value.
+func (tc *ttlCache) set(key string, value interface{}) {
+	tc.mu.Lock()
+	defer tc.mu.Unlock()
+	tc.entries[key] = cacheEntry{
+		value:   value,
+		expires: time.Now().Add(tc.ttl),
+	}
+}
@@ -2,9 +2,11 @@ package robinhood
 
 import (
 	"encoding/json"
+	"fmt"
 	"log"
 	"net/url"
 	"strconv"
+	"strings"
 	"time"
 )
 
@@ -16,7 +18,7 @@ type Chain struct {
 	Strike     float64
 	Expiration time.Time
 	Symbol     string
-	Type       string // "put" or "call". TODO: use an enum?
+	Type       OptionType
 
 	// Private fields
 	id string
@@ -25,34 +27,46 @@ type Chain struct {
 // Chains returns all chains (i.e. a complete option with strike price) for
 // an option on the underlying symbol and an expiration date.
 func (c *Client) Chains(symbol string, expiration time.Time) ([]Chain, error) {
-	chains, err := c.chains(symbol, expiration)
+	chains, err := c.chains(symbol, []string{expiration.Format(dateFormat)})
 	if err != nil {
 		return nil, err
 	}
 	// Convert internal format to external format.
 	var Chains []Chain
 	for _, c := range chains {
-		strike, err := strconv.ParseFloat(c.StrikePrice, 64)
+		ch, err := newChain(symbol, c)
 		if err != nil {
-			log.Printf("error converting to float %q: %v", c.StrikePrice, err)
+			log.Print(err)
 			continue
 		}
-		exp, err := time.Parse(dateFormat, c.ExpirationDate)
-		if err != nil {
-			log.Printf("error parsing expiration date %q: %v", c.ExpirationDate, err)
-			continue
-		}
-		Chains = append(Chains, Chain{
-			Symbol:     symbol,
-			Type:       c.Type,
-			Strike:     strike,
-			Expiration: exp,
-			id:         c.ID,
-		})
+		Chains = append(Chains, ch)
 	}
 	return Chains, nil
 }
 
+// newChain converts c, an option on symbol, to the external format.
+func newChain(symbol string, c chain) (Chain, error) {
+	strike, err := strconv.ParseFloat(c.StrikePrice, 64)
+	if err != nil {
+		return Chain{}, fmt.Errorf("error converting to float %q: %v", c.StrikePrice, err)
+	}
+	exp, err := time.Parse(dateFormat, c.ExpirationDate)
+	if err != nil {
+		return Chain{}, fmt.Errorf("error parsing expiration date %q: %v", c.ExpirationDate, err)
+	}
+	typ, err := ParseOptionType(c.Type)
+	if err != nil {
+		return Chain{}, err
+	}
+	return Chain{
+		Symbol:     symbol,
+		Type:       typ,
+		Strike:     strike,
+		Expiration: exp,
+		id:         c.ID,
+	}, nil
+}
+
 type chain struct {
 	ID             string     `json:"id"`
 	InstrumentID   Instrument `json:"instrument"`
@@ -61,18 +75,21 @@ type chain struct {
 	StrikePrice    string     `json:"strike_price"`
 	ExpirationDate string     `json:"expiration_date"`
 	Type           string     `json:"type"` // "put" or "call"
+	ChainSymbol    string     `json:"chain_symbol"`
 }
 
-func (c *Client) chains(symbol string, expiration time.Time) ([]chain, error) {
+// chains returns the tradable chains of symbol expiring on any of the given
+// dates, formatted as dateFormat.
+func (c *Client) chains(symbol string, dates []string) ([]chain, error) {
 	exp, err := c.expirations(symbol)
 	if err != nil {
 		return nil, err
 	}
 
-	// Fetch the strikes for this option ID at a given expiration date.
+	// Fetch the strikes for this option ID at the given expiration dates.
 	parms := url.Values{}
 	parms.Set("chain_id", exp.ID)
-	parms.Set("expiration_dates", expiration.Format(dateFormat))
+	parms.Set("expiration_dates", strings.Join(dates, ","))
 	parms.Set("state", "active")
 	parms.Set("tradability", "tradable")
 
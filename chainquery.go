@@ -0,0 +1,154 @@
+package robinhood
+
+// This file deals with selecting option contracts by strike, expiration and
+// delta.
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// Range is an inclusive range of values.
+type Range struct {
+	Min float64
+	Max float64
+}
+
+// contains returns whether v is within r. A nil Range contains everything.
+func (r *Range) contains(v float64) bool {
+	return r == nil || (v >= r.Min && v <= r.Max)
+}
+
+// ChainQuery selects option contracts. Nil ranges and a zero Type select
+// everything.
+type ChainQuery struct {
+	Type   OptionType // Zero for both calls and puts.
+	Strike *Range
+
+	// Moneyness selects strikes relative to the price of the underlying, as
+	// (strike - price) / price. For example, {-0.05, 0.05} selects strikes
+	// within 5% of the price.
+	Moneyness *Range
+
+	// DaysToExpiration selects expirations by the number of calendar days
+	// from Now until expiration.
+	DaysToExpiration *Range
+
+	// Delta selects contracts by their delta. Put deltas are negative.
+	// Contracts without market data are never selected.
+	Delta *Range
+
+	// Now is the time DaysToExpiration is relative to. If zero, the current
+	// time is used.
+	Now time.Time
+}
+
+// QueryChains returns a snapshot of the options of symbol selected by q. The
+// underlying quote is only fetched when filtering by Moneyness, and option
+// quotes only when filtering by Delta; in the latter case, the snapshot has
+// market data.
+func (c *Client) QueryChains(symbol string, q ChainQuery) (*OptionChain, error) {
+	var req OptionChainRequest
+	if q.DaysToExpiration != nil {
+		now := q.now()
+		req.From = now.AddDate(0, 0, int(math.Ceil(q.DaysToExpiration.Min)))
+		req.To = now.AddDate(0, 0, int(math.Floor(q.DaysToExpiration.Max)))
+	}
+	req.MarketData = q.Delta != nil
+	var price float64
+	if q.Moneyness != nil {
+		quotes, err := c.Quote([]string{symbol})
+		if err != nil {
+			return nil, err
+		}
+		if len(quotes) != 1 {
+			return nil, fmt.Errorf("expected one quote for symbol %s, got %d", symbol, len(quotes))
+		}
+		price = quotes[0].Price()
+	}
+	oc, err := c.OptionChain(symbol, req)
+	if err != nil {
+		return nil, err
+	}
+	return oc.Filter(q, price), nil
+}
+
+// Filter returns a snapshot with the contracts of oc selected by q. The price
+// of the underlying is only needed when filtering by Moneyness.
+func (oc *OptionChain) Filter(q ChainQuery, price float64) *OptionChain {
+	now := q.now()
+	return oc.subset(func(i int) bool {
+		ch := oc.Chains[i]
+		if q.Type != 0 && ch.Type != q.Type {
+			return false
+		}
+		if !q.Strike.contains(ch.Strike) {
+			return false
+		}
+		if q.Moneyness != nil && (price <= 0 || !q.Moneyness.contains((ch.Strike-price)/price)) {
+			return false
+		}
+		if !q.DaysToExpiration.contains(daysBetween(now, ch.Expiration)) {
+			return false
+		}
+		if q.Delta != nil {
+			if i >= len(oc.Options) || oc.Options[i].Symbol == "" || !q.Delta.contains(oc.Options[i].Greeks.Delta) {
+				return false
+			}
+		}
+		return true
+	})
+}
+
+// NearestStrike returns the contract of the given type expiring on the given
+// date whose strike is closest to price. Ties go to the lower strike.
+func (oc *OptionChain) NearestStrike(expiration time.Time, typ OptionType, price float64) (Chain, bool) {
+	var best Chain
+	found := false
+	for _, ch := range oc.ByExpiration(expiration) {
+		if ch.Type != typ {
+			continue
+		}
+		if !found || math.Abs(ch.Strike-price) < math.Abs(best.Strike-price) {
+			best = ch
+			found = true
+		}
+	}
+	return best, found
+}
+
+// subset returns a snapshot with the contracts for which keep returns true.
+func (oc *OptionChain) subset(keep func(i int) bool) *OptionChain {
+	sub := &OptionChain{
+		Symbol: oc.Symbol,
+		index:  make(map[chainKey]int),
+	}
+	for i, ch := range oc.Chains {
+		if !keep(i) {
+			continue
+		}
+		if n := len(sub.Expirations); n == 0 || !sub.Expirations[n-1].Equal(ch.Expiration) {
+			sub.Expirations = append(sub.Expirations, ch.Expiration)
+		}
+		sub.index[newChainKey(ch.Expiration, ch.Strike, ch.Type)] = len(sub.Chains)
+		sub.Chains = append(sub.Chains, ch)
+		if i < len(oc.Options) {
+			sub.Options = append(sub.Options, oc.Options[i])
+		}
+	}
+	return sub
+}
+
+func (q ChainQuery) now() time.Time {
+	if q.Now.IsZero() {
+		return time.Now()
+	}
+	return q.Now
+}
+
+// daysBetween returns the number of calendar days from the date of now to the
+// expiration date.
+func daysBetween(now, expiration time.Time) float64 {
+	return math.Round(truncateDate(expiration).Sub(truncateDate(now)).Hours() / 24)
+}
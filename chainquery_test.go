@@ -0,0 +1,107 @@
+package robinhood
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	httpmock "gopkg.in/jarcoal/httpmock.v1"
+)
+
+func TestFilter(t *testing.T) {
+	now := time.Date(2018, 6, 1, 15, 0, 0, 0, time.UTC)
+	near := time.Date(2018, 6, 29, 0, 0, 0, 0, time.UTC) // 28 days out.
+	far := time.Date(2018, 9, 21, 0, 0, 0, 0, time.UTC)  // 112 days out.
+	all := &OptionChain{Symbol: "SPY"}
+	for _, exp := range []time.Time{near, far} {
+		for _, strike := range []float64{90, 95, 100, 105, 110} {
+			for _, typ := range []OptionType{Call, Put} {
+				delta := (110 - strike) / 20
+				if typ == Put {
+					delta -= 1
+				}
+				ch := Chain{Symbol: "SPY", Strike: strike, Expiration: exp, Type: typ}
+				all.Chains = append(all.Chains, ch)
+				all.Options = append(all.Options, Option{Symbol: "SPY", Strike: strike, Expiration: exp, Type: typ, Greeks: Greeks{Delta: delta}})
+			}
+		}
+	}
+	all = all.subset(func(int) bool { return true })
+
+	tests := []struct {
+		name  string
+		query ChainQuery
+		want  []string // "strike type expiration"
+	}{
+		{"type and strike", ChainQuery{Type: Put, Strike: &Range{95, 100}}, []string{
+			"95 put 2018-06-29", "100 put 2018-06-29", "95 put 2018-09-21", "100 put 2018-09-21"}},
+		{"moneyness and days", ChainQuery{Type: Call, Moneyness: &Range{-0.06, 0.05}, DaysToExpiration: &Range{20, 60}}, []string{
+			"95 call 2018-06-29", "100 call 2018-06-29", "105 call 2018-06-29"}},
+		{"delta", ChainQuery{Delta: &Range{-0.30, -0.20}}, []string{
+			"95 put 2018-06-29", "95 put 2018-09-21"}},
+		{"days only", ChainQuery{DaysToExpiration: &Range{100, 120}, Strike: &Range{110, 200}}, []string{
+			"110 call 2018-09-21", "110 put 2018-09-21"}},
+	}
+	for _, test := range tests {
+		test.query.Now = now
+		got := all.Filter(test.query, 100)
+		var gotStrs []string
+		for i, ch := range got.Chains {
+			gotStrs = append(gotStrs, strings.Join([]string{strconv.FormatFloat(ch.Strike, 'f', -1, 64), ch.Type.String(), ch.Expiration.Format(dateFormat)}, " "))
+			if got.Options[i].Strike != ch.Strike || got.Options[i].Type != ch.Type {
+				t.Errorf("%s: options not aligned to chains: %v vs %v", test.name, got.Options[i], ch)
+			}
+		}
+		if strings.Join(gotStrs, ",") != strings.Join(test.want, ",") {
+			t.Errorf("%s: got %v, want %v", test.name, gotStrs, test.want)
+		}
+		for _, ch := range got.Chains {
+			if _, ok := got.Chain(ch.Expiration, ch.Strike, ch.Type); !ok {
+				t.Errorf("%s: filtered snapshot not indexed", test.name)
+			}
+		}
+	}
+
+	ch, ok := all.NearestStrike(far, Put, 103)
+	if !ok || ch.Strike != 105 || ch.Type != Put || !ch.Expiration.Equal(far) {
+		t.Errorf("NearestStrike(103) = %v, %v; want 105 put", ch, ok)
+	}
+	ch, ok = all.NearestStrike(near, Call, 102.5)
+	if !ok || ch.Strike != 100 {
+		t.Errorf("NearestStrike(102.5) = %v, %v; want 100 call", ch, ok)
+	}
+	if _, ok := all.NearestStrike(near.AddDate(0, 0, 1), Call, 100); ok {
+		t.Error("NearestStrike found a strike on a date without expirations")
+	}
+}
+
+func TestQueryChains(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	for url, reply := range optionChainReplies {
+		method := "GET"
+		if strings.Contains(url, oAuthUpgradeURI) {
+			method = "POST"
+		}
+		httpmock.RegisterResponder(method, url, httpmock.NewStringResponder(200, reply))
+	}
+	httpmock.RegisterResponder("GET", apiURL+quotesURI+"?symbols=SPY", httpmock.NewStringResponder(200,
+		`{"results":[{"ask_price":"297.1000","bid_price":"296.9000","last_trade_price":"297.0000","symbol":"SPY"}]}`))
+
+	c := Client{Token: "token"}
+	oc, err := c.QueryChains("SPY", ChainQuery{
+		Type:             Call,
+		Moneyness:        &Range{0, 0.01},
+		DaysToExpiration: &Range{0, 4},
+		Delta:            &Range{0.4, 0.5},
+		Now:              time.Date(2018, 6, 28, 12, 0, 0, 0, time.UTC),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(oc.Chains) != 1 || oc.Chains[0].id != "id-0702-298-call" || oc.Options[0].Greeks.Delta != 0.45 {
+		t.Errorf("got %v, %v; want the 298 call expiring 2018-07-02", oc.Chains, oc.Options)
+	}
+}
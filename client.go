@@ -11,7 +11,8 @@ import (
 )
 
 // Client is the Robinhood API client. It supports a single account. For users
-// with multiple accounts, create a new Client for each account.
+// with multiple accounts, use a Session to get a Client for each account that
+// shares authentication and caches with the others.
 type Client struct {
 	// AccountID is the account number this client will use. It is required for
 	// all operations that operate directly on a user's account, such as calls to
@@ -37,8 +38,61 @@ type Client struct {
 	// BearerTokenExpiration is the wall clock time that the bearer token expires.
 	BearerTokenExpiration time.Time
 
-	once       sync.Once
-	httpClient *http.Client
+	// CacheTTL is how long reference data, such as instruments, is cached by
+	// this client. If zero, DefaultCacheTTL is used. It must be set before the
+	// first request.
+	CacheTTL time.Duration
+
+	// MaxConcurrentRequests limits how many requests this client has in flight
+	// at any time. If zero, DefaultMaxConcurrentRequests is used. It must be
+	// set before the first request.
+	MaxConcurrentRequests int
+
+	// AllowTransfers must be true for the client to move money to or from a
+	// bank account, with Transfer and CancelTransfer. It guards against doing
+	// so by mistake.
+	AllowTransfers bool
+
+	// parent is the session's client this client shares tokens, caches and
+	// its request limit with, or nil.
+	parent *Client
+
+	once         sync.Once
+	httpClient   *http.Client
+	instruments  *ttlCache
+	hours        *ttlCache
+	optionChains *ttlCache
+	inFlight     chan struct{} // Semaphore limiting concurrent requests.
+	tokenMu      sync.Mutex    // Guards refreshing the bearer token.
+}
+
+// DefaultMaxConcurrentRequests is the number of requests a client issues
+// concurrently when Client.MaxConcurrentRequests is zero.
+const DefaultMaxConcurrentRequests = 4
+
+// init lazily initializes the client's internal state so that the zero Client
+// is ready to use.
+func (c *Client) init() {
+	c.once.Do(func() {
+		if c.parent != nil {
+			c.parent.init()
+			c.httpClient = c.parent.httpClient
+			c.instruments = c.parent.instruments
+			c.hours = c.parent.hours
+			c.optionChains = c.parent.optionChains
+			c.inFlight = c.parent.inFlight
+			return
+		}
+		c.httpClient = &http.Client{}
+		c.instruments = newTTLCache(c.CacheTTL)
+		c.hours = newTTLCache(c.CacheTTL)
+		c.optionChains = newTTLCache(c.CacheTTL)
+		n := c.MaxConcurrentRequests
+		if n <= 0 {
+			n = DefaultMaxConcurrentRequests
+		}
+		c.inFlight = make(chan struct{}, n)
+	})
 }
 
 type token struct {
@@ -72,27 +126,6 @@ func (c *Client) GetToken() error {
 	return nil
 }
 
-// Account contains the user's AccountNumber.
-type Account struct {
-	AccountNumber string `json:"account_number"`
-	URL           string `json:"url"`
-}
-
-// GetAccounts returns the list of all account numbers associated with a user.
-// Client must be authenticated (i.e. a Token must be supplied).
-func (c *Client) GetAccounts() ([]Account, error) {
-	resp, err := c.paginatedGet(accountsURI)
-	if err != nil {
-		return nil, err
-	}
-	var accs []Account
-	err = json.Unmarshal(resp, &accs)
-	if err != nil {
-		return nil, err
-	}
-	return accs, nil
-}
-
 /*
    "token_type": "Bearer",
    "access_token": "9Lg%WiectYtobuiewceIVUnhjiBGLUIeytekLBGJKDHGfvhjkfkuggbusfhukewrygfubasd",
@@ -130,11 +163,26 @@ func (c *Client) GetBearerToken() error {
 // EnsureBearerToken ensures the client has a bearer token with at least another
 // 30 seconds of time to live.
 func (c *Client) EnsureBearerToken() error {
+	_, err := c.bearerToken()
+	return err
+}
+
+// bearerToken returns a bearer token with at least another 30 seconds of time
+// to live, fetching a new one if needed. It is safe for concurrent use.
+func (c *Client) bearerToken() (string, error) {
+	if c.parent != nil {
+		return c.parent.bearerToken()
+	}
+	c.tokenMu.Lock()
+	defer c.tokenMu.Unlock()
 	// Do we still have 30 seconds left to use the token?
-	if c.BearerTokenExpiration.After(time.Now().Add(30 * time.Second)) {
-		return nil
+	if !c.BearerTokenExpiration.After(time.Now().Add(30 * time.Second)) {
+		err := c.GetBearerToken()
+		if err != nil {
+			return "", err
+		}
 	}
-	return c.GetBearerToken()
+	return c.BearerToken, nil
 }
 
 // parseFloat64 parses the float and returns the prevErr if non null or the
@@ -156,3 +204,13 @@ func parseOptionalFloat64(str string, prevErr error) (float64, error) {
 	}
 	return parseFloat64(str, prevErr)
 }
+
+// parseTime parses an RFC 3339 timestamp. Like parseFloat64, it returns
+// prevErr if not nil.
+func parseTime(str string, prevErr error) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, str)
+	if prevErr != nil {
+		return t, prevErr
+	}
+	return t, err
+}
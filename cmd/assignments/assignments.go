@@ -0,0 +1,87 @@
+// assignments lists option positions expiring soon and their risk of
+// assignment.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	rh "github.com/edpin/robinhood"
+)
+
+var (
+	token  = flag.String("token", "", "User's access token with Robinhood")
+	days   = flag.Int("days", 7, "Report options expiring within this many days")
+	exDivs = flag.String("exdiv", "", "Upcoming ex-dividend dates, as SYMBOL:YYYY-MM-DD:AMOUNT,...")
+)
+
+const dateFormat = "2006-01-02"
+
+func main() {
+	flag.Parse()
+
+	if *token == "" {
+		fmt.Printf(`
+Usage:
+  assignments --token=<auth_token> [--days=7] [--exdiv=T:2018-07-06:0.50,...]
+`)
+		return
+	}
+	dividends, err := parseExDividends(*exDivs)
+	if err != nil {
+		panic(err)
+	}
+	client := &rh.Client{
+		Token: *token,
+	}
+	report, err := client.AssignmentReport(*days, time.Now(), dividends)
+	switch err.(type) {
+	case nil, *rh.MissingMarketDataError, *rh.UnknownSymbolsError:
+	default:
+		panic(err)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "OPTION\tSIDE\tQTY\tDAYS\tSPOT\tMONEYNESS\tEXTRINSIC\tEX-DIV\tRISK")
+	for _, e := range report {
+		exDiv := "-"
+		if e.ExDividend != nil {
+			exDiv = fmt.Sprintf("%s (%.2f)", e.ExDividend.Date.Format(dateFormat), e.ExDividend.Amount)
+		}
+		fmt.Fprintf(w, "%s\t%s\t%v\t%d\t%.2f\t%+.2f%%\t%.2f\t%s\t%s\n",
+			e.Position.Chain.OCC(), e.Position.Direction, e.Position.Quantity, e.DaysToExpiration,
+			e.Spot, e.Moneyness*100, e.Extrinsic, exDiv, e.Risk)
+	}
+	w.Flush()
+}
+
+// parseExDividends parses a list of SYMBOL:YYYY-MM-DD:AMOUNT entries.
+func parseExDividends(s string) (map[string]rh.ExDividend, error) {
+	dividends := make(map[string]rh.ExDividend)
+	if s == "" {
+		return dividends, nil
+	}
+	for _, entry := range strings.Split(s, ",") {
+		parts := strings.Split(entry, ":")
+		if len(parts) != 3 {
+			return nil, fmt.Errorf("invalid ex-dividend %q, want SYMBOL:YYYY-MM-DD:AMOUNT", entry)
+		}
+		date, err := time.Parse(dateFormat, parts[1])
+		if err != nil {
+			return nil, err
+		}
+		amount, err := strconv.ParseFloat(parts[2], 64)
+		if err != nil {
+			return nil, err
+		}
+		dividends[strings.ToUpper(parts[0])] = rh.ExDividend{Date: date, Amount: amount}
+	}
+	return dividends, nil
+}
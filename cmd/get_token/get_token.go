@@ -4,6 +4,7 @@ package main
 import (
 	"flag"
 	"fmt"
+	"os"
 
 	rh "github.com/edpin/robinhood"
 )
@@ -47,11 +48,14 @@ Usage:
 	}
 
 	accs, err := client.GetAccounts()
-	if err != nil {
+	if _, ok := err.(*rh.UnparsedAccountsError); err != nil && !ok {
 		panic(err)
 	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
 	fmt.Println("Accounts:")
 	for _, acc := range accs {
-		fmt.Printf("Account: %v\n", acc.AccountNumber)
+		fmt.Printf("Account: %v (%s), buying power: %.2f\n", acc.AccountNumber, acc.Type, acc.BuyingPower)
 	}
 }
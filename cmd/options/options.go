@@ -4,7 +4,6 @@ package main
 import (
 	"flag"
 	"fmt"
-	"math"
 	"time"
 
 	rh "github.com/edpin/robinhood"
@@ -36,31 +35,23 @@ Usage:
 	if err != nil {
 		panic(err)
 	}
-	chains, err := client.Chains(*symbol, exp)
+	typ, err := rh.ParseOptionType(*optType)
 	if err != nil {
 		panic(err)
 	}
-	// Look for the right strike price.
-	idx := -1
-	for i, chain := range chains {
-		if floatEquals(*strike, chain.Strike) {
-			idx = i
-			break
-		}
+	oc, err := client.OptionChain(*symbol, rh.OptionChainRequest{From: exp, To: exp})
+	if err != nil {
+		panic(err)
 	}
-	if idx < 0 {
+	// Look for the right strike price.
+	chain, ok := oc.Chain(exp, *strike, typ)
+	if !ok {
 		panic("no chain found")
 	}
 
-	opt, err := client.Option(chains[idx])
+	opt, err := client.Option(chain)
 	if err != nil {
 		panic(err)
 	}
 	fmt.Printf("%+v\n", opt)
 }
-
-const fEpsilon = 0.00001
-
-func floatEquals(a, b float64) bool {
-	return math.Abs(a-b) < fEpsilon
-}
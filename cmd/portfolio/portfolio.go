@@ -29,11 +29,23 @@ Usage:
 		Token:     *token,
 	}
 	port, err := client.Portfolio()
-	if err != nil {
+	switch err.(type) {
+	case nil:
+	case *rh.UnknownSymbolsError, *rh.UnresolvedPositionsError:
+		fmt.Printf("Warning: %v\n", err)
+	default:
 		panic(err)
 	}
 	fmt.Printf("Porfolio:\n")
 	for _, p := range port {
 		fmt.Printf("%+v\n", p)
 	}
+	opts, err := client.OptionPositions()
+	if _, ok := err.(*rh.MissingMarketDataError); err != nil && !ok {
+		panic(err)
+	}
+	fmt.Printf("Options:\n")
+	for _, p := range opts {
+		fmt.Printf("%s %s x%v avg %.2f value %.2f\n", p.Direction, p.Chain.OCC(), p.Quantity, p.AveragePrice, p.MarketValue)
+	}
 }
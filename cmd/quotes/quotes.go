@@ -30,7 +30,9 @@ Usage:
 	symbols := strings.Split(symbs, ",")
 
 	quotes, err := client.Quote(symbols)
-	if err != nil {
+	if unknown, ok := err.(*rh.UnknownSymbolsError); ok {
+		fmt.Printf("Unknown symbols: %v\n", unknown.Symbols)
+	} else if err != nil {
 		panic(err)
 	}
 	fmt.Printf("Quotes:\n")
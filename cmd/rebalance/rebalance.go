@@ -0,0 +1,86 @@
+// rebalance previews, and optionally places, the trades that bring the user's
+// portfolio to target weights.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	rh "github.com/edpin/robinhood"
+	"github.com/edpin/robinhood/rebalance"
+)
+
+var (
+	token      = flag.String("token", "", "User's access token with Robinhood")
+	account    = flag.String("account", "", "Account number to rebalance")
+	targets    = flag.String("targets", "", "Target weights, as SYMBOL:WEIGHT,... with weights adding up to at most 1")
+	reserve    = flag.Float64("reserve", 0, "Cash to keep uninvested")
+	minTrade   = flag.Float64("min_trade", 0, "Smallest trade value worth making")
+	drift      = flag.Float64("drift", 0, "Leave positions within this weight of their target alone")
+	fractional = flag.Bool("fractional", false, "Allow fractional shares in the preview")
+	sellOthers = flag.Bool("sell_untargeted", false, "Sell positions without a target")
+	submit     = flag.Bool("submit", false, "Place the orders instead of only previewing them")
+)
+
+func main() {
+	flag.Parse()
+
+	if *token == "" || *account == "" || *targets == "" {
+		fmt.Printf(`
+Usage:
+  rebalance --token=<auth_token> --account=<account> --targets=VTI:0.6,BND:0.4
+            [--reserve=0] [--min_trade=0] [--drift=0] [--fractional]
+            [--sell_untargeted] [--submit]
+`)
+		return
+	}
+	t, err := parseTargets(*targets)
+	if err != nil {
+		panic(err)
+	}
+	client := &rh.Client{
+		Token:     *token,
+		AccountID: *account,
+	}
+	plan, err := rebalance.Fetch(client, t, rebalance.Params{
+		Reserve:        *reserve,
+		MinTrade:       *minTrade,
+		Drift:          *drift,
+		Fractional:     *fractional,
+		SellUntargeted: *sellOthers,
+	})
+	if err != nil {
+		panic(err)
+	}
+	plan.WriteText(os.Stdout)
+	if !*submit || len(plan.Trades) == 0 {
+		return
+	}
+	placed, err := plan.Submit(client)
+	for _, t := range placed {
+		fmt.Printf("Placed order to %s %v %s.\n", t.Side, t.Quantity, t.Symbol)
+	}
+	if err != nil {
+		panic(err)
+	}
+}
+
+// parseTargets parses a list of SYMBOL:WEIGHT entries.
+func parseTargets(s string) (rebalance.Targets, error) {
+	targets := make(rebalance.Targets)
+	for _, entry := range strings.Split(s, ",") {
+		parts := strings.Split(entry, ":")
+		if len(parts) != 2 {
+			return nil, fmt.Errorf("invalid target %q, want SYMBOL:WEIGHT", entry)
+		}
+		w, err := strconv.ParseFloat(parts[1], 64)
+		if err != nil {
+			return nil, err
+		}
+		targets[strings.ToUpper(parts[0])] = w
+	}
+	return targets, nil
+}
@@ -0,0 +1,65 @@
+// statement exports the user's account activity — orders, fills, dividends,
+// transfers, fees and option events — as CSV, JSON, OFX or QIF.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"time"
+
+	rh "github.com/edpin/robinhood"
+	"github.com/edpin/robinhood/ledger"
+)
+
+var (
+	token   = flag.String("token", "", "User's access token with Robinhood")
+	account = flag.String("account", "", "Account number to export, all if empty; required for OFX")
+	format  = flag.String("format", "csv", "Output format: csv, json, ofx or qif")
+)
+
+func main() {
+	flag.Parse()
+
+	if *token == "" || (*format == "ofx" && *account == "") {
+		fmt.Printf(`
+Usage:
+  statement --token=<auth_token> [--format=csv|json|qif]
+  statement --token=<auth_token> --account=<account> --format=ofx
+`)
+		return
+	}
+	client := &rh.Client{
+		Token:     *token,
+		AccountID: *account,
+	}
+	acts, err := ledger.Fetch(client)
+	if _, ok := err.(*rh.UnresolvedInstrumentsError); err != nil && !ok {
+		panic(err)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	switch *format {
+	case "csv":
+		err = ledger.WriteCSV(os.Stdout, acts)
+	case "json":
+		err = ledger.WriteJSON(os.Stdout, acts)
+	case "qif":
+		err = ledger.WriteQIF(os.Stdout, acts)
+	case "ofx":
+		var acc rh.Account
+		acc, err = client.Account()
+		if err != nil {
+			panic(err)
+		}
+		st := ledger.Statement{Account: *account, Balance: acc.Cash, AsOf: time.Now()}
+		err = ledger.WriteOFX(os.Stdout, st, acts)
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown format %q\n", *format)
+		os.Exit(2)
+	}
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,68 @@
+// taxlots reconstructs tax lots from the user's order history and writes the
+// gains realized in a year as a Form 8949 CSV.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	rh "github.com/edpin/robinhood"
+	"github.com/edpin/robinhood/taxlot"
+)
+
+var (
+	token   = flag.String("token", "", "User's access token with Robinhood")
+	account = flag.String("account", "", "Account number, all accounts if empty")
+	year    = flag.Int("year", time.Now().Year()-1, "Tax year to report")
+	method  = flag.String("method", "fifo", "How lots are chosen: fifo or lifo")
+	open    = flag.Bool("open", false, "List the open lots instead of the gains")
+)
+
+func main() {
+	flag.Parse()
+
+	if *token == "" {
+		fmt.Printf(`
+Usage:
+  taxlots --token=<auth_token> [--account=<account>] [--year=2018] [--method=fifo|lifo] [--open]
+`)
+		return
+	}
+	m := taxlot.FIFO
+	switch *method {
+	case "fifo":
+	case "lifo":
+		m = taxlot.LIFO
+	default:
+		fmt.Fprintf(os.Stderr, "Unknown method %q\n", *method)
+		os.Exit(2)
+	}
+	client := &rh.Client{
+		Token:     *token,
+		AccountID: *account,
+	}
+	ledger, err := taxlot.Fetch(client, m, time.Now())
+	if _, ok := err.(*rh.UnresolvedInstrumentsError); err != nil && !ok {
+		panic(err)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Warning: %v\n", err)
+	}
+	if !*open {
+		err = taxlot.WriteForm8949(os.Stdout, ledger.RealizedIn(*year))
+		if err != nil {
+			panic(err)
+		}
+		return
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(w, "SECURITY\tSIDE\tQTY\tACQUIRED\tBASIS\tWASH SALE")
+	for _, l := range ledger.Open {
+		fmt.Fprintf(w, "%s\t%s\t%v\t%s\t%.2f\t%.2f\n",
+			l.Security, l.Direction, l.Quantity, l.Acquired.Format("2006-01-02"), l.Basis, l.WashSale)
+	}
+	w.Flush()
+}
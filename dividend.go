@@ -0,0 +1,179 @@
+package robinhood
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// This file deals with dividends paid to the user and projected income.
+
+// Dividend is a dividend paid, or to be paid, on a position of the user.
+type Dividend struct {
+	ID         string
+	Account    string // URL of the account.
+	Instrument Instrument
+	Symbol     string // Empty if Instrument can't be resolved.
+
+	Amount      float64 // Total amount, Rate × Position.
+	Rate        float64 // Per share.
+	Position    float64 // Shares held on the record date.
+	Withholding float64 // Tax withheld.
+
+	RecordDate  time.Time
+	PayableDate time.Time
+	PaidAt      time.Time // Zero until paid.
+
+	// State is "pending", "paid", "reinvested" or "voided".
+	State string
+}
+
+// Dividends returns all dividends of the user, of every account, with their
+// symbols.
+//
+// If the instruments of some dividends can't be resolved, Dividends returns
+// all dividends along with an *UnresolvedInstrumentsError listing them; those
+// dividends have no Symbol.
+func (c *Client) Dividends() ([]Dividend, error) {
+	resp, err := c.paginatedGet(dividendsURI)
+	if err != nil {
+		return nil, err
+	}
+	var divs []dividend
+	err = json.Unmarshal(resp, &divs)
+	if err != nil {
+		return nil, err
+	}
+	dividends := make([]Dividend, len(divs))
+	urls := make([]Instrument, len(divs))
+	for i, d := range divs {
+		amount, err := parseFloat64(d.Amount, nil)
+		rate, err := parseFloat64(d.Rate, err)
+		position, err := parseFloat64(d.Position, err)
+		withholding, err := parseOptionalFloat64(d.Withholding, err)
+		var recordDate, payableDate, paidAt time.Time
+		if err == nil {
+			recordDate, err = time.Parse(dateFormat, d.RecordDate)
+		}
+		if err == nil {
+			payableDate, err = time.Parse(dateFormat, d.PayableDate)
+		}
+		if d.PaidAt != "" {
+			paidAt, err = parseTime(d.PaidAt, err)
+		}
+		if err != nil {
+			return nil, err
+		}
+		dividends[i] = Dividend{
+			ID:          d.ID,
+			Account:     d.Account,
+			Instrument:  d.Instrument,
+			Amount:      amount,
+			Rate:        rate,
+			Position:    position,
+			Withholding: withholding,
+			RecordDate:  recordDate,
+			PayableDate: payableDate,
+			PaidAt:      paidAt,
+			State:       d.State,
+		}
+		urls[i] = d.Instrument
+	}
+	insts, unresolved := c.resolveInstruments(urls)
+	for i, inst := range insts {
+		dividends[i].Symbol = inst.Symbol
+	}
+	if unresolved != nil {
+		return dividends, unresolved
+	}
+	return dividends, nil
+}
+
+type dividend struct {
+	ID          string     `json:"id"`
+	Account     string     `json:"account"`
+	Instrument  Instrument `json:"instrument"`
+	Amount      string     `json:"amount"`
+	Rate        string     `json:"rate"`
+	Position    string     `json:"position"`
+	Withholding string     `json:"withholding"`
+	RecordDate  string     `json:"record_date"`
+	PayableDate string     `json:"payable_date"`
+	PaidAt      string     `json:"paid_at"`
+	State       string     `json:"state"`
+}
+
+// IncomeProjection is the dividend income expected from a position over the
+// next 12 months.
+type IncomeProjection struct {
+	Symbol   string
+	Quantity float64
+	// AnnualRate is the sum of the dividends per share paid over the last 12
+	// months.
+	AnnualRate float64
+	Income     float64 // AnnualRate × Quantity.
+	// Yield is AnnualRate as a fraction of the current price. Zero if the
+	// price is unknown.
+	Yield float64
+}
+
+// ProjectIncome estimates the dividend income of positions over the 12 months
+// after now, assuming each stock pays the same dividends per share it paid in
+// the 12 months before now. Only dividends in history count, so stocks the
+// user didn't hold in that time are projected to pay nothing.
+func ProjectIncome(positions []Position, history []Dividend, now time.Time) []IncomeProjection {
+	yearAgo := now.AddDate(-1, 0, 0)
+	rates := make(map[Instrument]float64)
+	seen := make(map[string]bool) // Dividends counted once across accounts.
+	for _, d := range history {
+		if d.State == "voided" || !d.PayableDate.After(yearAgo) || d.PayableDate.After(now) {
+			continue
+		}
+		key := string(d.Instrument) + " " + d.PayableDate.Format(dateFormat)
+		if seen[key] {
+			continue
+		}
+		seen[key] = true
+		rates[d.Instrument] += d.Rate
+	}
+	var projections []IncomeProjection
+	for _, p := range positions {
+		rate := rates[p.Instrument]
+		proj := IncomeProjection{
+			Symbol:     p.Symbol,
+			Quantity:   p.Quantity,
+			AnnualRate: rate,
+			Income:     rate * p.Quantity,
+		}
+		if p.Price != 0 {
+			proj.Yield = rate / p.Price
+		}
+		projections = append(projections, proj)
+	}
+	return projections
+}
+
+// ProjectedIncome estimates the dividend income of the client's portfolio over
+// the 12 months after now. See ProjectIncome.
+//
+// Like Portfolio and Dividends, it returns the projection along with an
+// *UnresolvedPositionsError or *UnknownSymbolsError if some positions or
+// dividends couldn't be fully resolved: their projections may lack a symbol or
+// a yield.
+func (c *Client) ProjectedIncome(now time.Time) ([]IncomeProjection, error) {
+	positions, partial := c.Portfolio()
+	switch partial.(type) {
+	case nil, *UnresolvedPositionsError, *UnknownSymbolsError:
+	default:
+		return nil, partial
+	}
+	history, err := c.Dividends()
+	if _, ok := err.(*UnresolvedInstrumentsError); err != nil && !ok {
+		return nil, err
+	}
+	if partial == nil {
+		partial = err
+	}
+	// Unresolved positions and dividends still have an Instrument to match
+	// on.
+	return ProjectIncome(positions, history, now), partial
+}
@@ -0,0 +1,129 @@
+package robinhood
+
+import (
+	"math"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	httpmock "gopkg.in/jarcoal/httpmock.v1"
+)
+
+const dividends = `{"previous":null,"results":[` +
+	`{"id":"d1","url":"https://api.robinhood.com/dividends/d1/","account":"https://api.robinhood.com/accounts/5RY82436/","instrument":"https://api.robinhood.com/instruments/aapl/","amount":"7.30","rate":"0.7300000000","position":"10.0000","withholding":"0.00","record_date":"2018-05-14","payable_date":"2018-05-17","paid_at":"2018-05-18T02:17:04.539131Z","state":"paid"},` +
+	`{"id":"d2","url":"https://api.robinhood.com/dividends/d2/","account":"https://api.robinhood.com/accounts/5RY82436/","instrument":"https://api.robinhood.com/instruments/aapl/","amount":"6.30","rate":"0.6300000000","position":"10.0000","withholding":"0.00","record_date":"2018-02-12","payable_date":"2018-02-15","paid_at":"2018-02-16T02:00:00Z","state":"paid"},` +
+	`{"id":"d3","url":"https://api.robinhood.com/dividends/d3/","account":"https://api.robinhood.com/accounts/5RY82436/","instrument":"https://api.robinhood.com/instruments/aapl/","amount":"5.70","rate":"0.5700000000","position":"10.0000","withholding":"0.00","record_date":"2017-05-12","payable_date":"2017-05-18","paid_at":"2017-05-19T02:00:00Z","state":"paid"},` +
+	`{"id":"d4","url":"https://api.robinhood.com/dividends/d4/","account":"https://api.robinhood.com/accounts/5RY82436/","instrument":"https://api.robinhood.com/instruments/gone/","amount":"1.00","rate":"0.1000000000","position":"10.0000","withholding":"0.15","record_date":"2018-06-01","payable_date":"2018-07-01","paid_at":null,"state":"pending"}` +
+	`],"next":null}`
+
+func TestDividends(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", apiURL+dividendsURI, httpmock.NewStringResponder(200, dividends))
+	var mu sync.Mutex
+	calls := 0
+	httpmock.RegisterResponder("GET", apiURL+instrumentsURI+"aapl/", func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+		return httpmock.NewStringResponse(200,
+			`{"id":"aapl","url":"https://api.robinhood.com/instruments/aapl/","symbol":"AAPL","simple_name":"Apple","tradeable":true}`), nil
+	})
+	httpmock.RegisterResponder("GET", apiURL+instrumentsURI+"gone/", httpmock.NewStringResponder(404, `{"detail":"Not found."}`))
+
+	c := Client{Token: "token"}
+	divs, err := c.Dividends()
+	unresolved, ok := err.(*UnresolvedInstrumentsError)
+	if !ok || len(unresolved.Instruments) != 1 {
+		t.Fatalf("err = %v, want one unresolved instrument", err)
+	}
+	if calls != 1 {
+		t.Errorf("instrument fetched %d times, want once", calls)
+	}
+	if len(divs) != 4 {
+		t.Fatalf("len(divs) = %d, want 4", len(divs))
+	}
+	want := Dividend{
+		ID:          "d1",
+		Account:     "https://api.robinhood.com/accounts/5RY82436/",
+		Instrument:  "https://api.robinhood.com/instruments/aapl/",
+		Symbol:      "AAPL",
+		Amount:      7.3,
+		Rate:        0.73,
+		Position:    10,
+		RecordDate:  time.Date(2018, 5, 14, 0, 0, 0, 0, time.UTC),
+		PayableDate: time.Date(2018, 5, 17, 0, 0, 0, 0, time.UTC),
+		PaidAt:      time.Date(2018, 5, 18, 2, 17, 4, 539131000, time.UTC),
+		State:       "paid",
+	}
+	if got := divs[0]; got != want {
+		t.Errorf("divs[0] = %+v, want %+v", got, want)
+	}
+	if pending := divs[3]; pending.Symbol != "" || !pending.PaidAt.IsZero() || pending.Withholding != 0.15 || pending.State != "pending" {
+		t.Errorf("pending = %+v", pending)
+	}
+}
+
+func TestProjectIncome(t *testing.T) {
+	aapl := Instrument("https://api.robinhood.com/instruments/aapl/")
+	payable := func(m time.Month, d int) time.Time { return time.Date(2018, m, d, 0, 0, 0, 0, time.UTC) }
+	history := []Dividend{
+		{Instrument: aapl, Rate: 0.73, PayableDate: payable(5, 17), State: "paid", Account: "a"},
+		{Instrument: aapl, Rate: 0.73, PayableDate: payable(5, 17), State: "paid", Account: "b"}, // Same dividend, another account.
+		{Instrument: aapl, Rate: 0.63, PayableDate: payable(2, 15), State: "paid"},
+		{Instrument: aapl, Rate: 0.63, PayableDate: payable(1, 2), State: "voided"},
+		{Instrument: aapl, Rate: 0.57, PayableDate: time.Date(2017, 5, 18, 0, 0, 0, 0, time.UTC), State: "paid"}, // Over a year ago.
+	}
+	positions := []Position{
+		{Instrument: aapl, Symbol: "AAPL", Quantity: 20, Price: 170.5},
+		{Instrument: "https://api.robinhood.com/instruments/brk/", Symbol: "BRK.B", Quantity: 5, Price: 190},
+	}
+	got := ProjectIncome(positions, history, payable(6, 22))
+	if len(got) != 2 {
+		t.Fatalf("len(projections) = %d, want 2", len(got))
+	}
+	if p := got[0]; p.Symbol != "AAPL" || math.Abs(p.AnnualRate-1.36) > 1e-9 || math.Abs(p.Income-27.2) > 1e-9 || math.Abs(p.Yield-1.36/170.5) > 1e-9 {
+		t.Errorf("AAPL = %+v", p)
+	}
+	if p := got[1]; p.AnnualRate != 0 || p.Income != 0 || p.Yield != 0 {
+		t.Errorf("BRK.B = %+v", p)
+	}
+}
+
+func TestProjectedIncomeUnresolved(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	aapl := "https://api.robinhood.com/instruments/450dfc6d-5510-4d40-abfb-f633b7d9be3e/"
+	gone := "https://api.robinhood.com/instruments/gone/"
+	httpmock.RegisterResponder("GET", apiURL+accountsURI+"account/"+positionsURI+"?nonzero=true", httpmock.NewStringResponder(200, `{"previous":null,"results":[`+
+		`{"created_at":"2018-03-01T14:30:05Z","updated_at":"2018-03-01T14:30:05Z","average_buy_price":"150.0000","instrument":"`+aapl+`","quantity":"10.0000"},`+
+		`{"created_at":"2018-03-01T14:30:05Z","updated_at":"2018-03-01T14:30:05Z","average_buy_price":"1.0000","instrument":"`+gone+`","quantity":"3.0000"}`+
+		`],"next":null}`))
+	httpmock.RegisterResponder("GET", aapl, httpmock.NewStringResponder(200,
+		`{"id":"450dfc6d-5510-4d40-abfb-f633b7d9be3e","url":"`+aapl+`","symbol":"AAPL","simple_name":"Apple","tradeable":true}`))
+	httpmock.RegisterResponder("GET", gone, httpmock.NewStringResponder(404, `{"detail":"Not found."}`))
+	httpmock.RegisterResponder("GET", apiURL+quotesURI+"?symbols=AAPL", httpmock.NewStringResponder(200,
+		`{"results":[{"ask_price":"180.0100","bid_price":"179.9900","last_trade_price":"180.0000","symbol":"AAPL"}]}`))
+	httpmock.RegisterResponder("GET", apiURL+dividendsURI, httpmock.NewStringResponder(200, `{"previous":null,"results":[`+
+		`{"id":"d1","account":"https://api.robinhood.com/accounts/account/","instrument":"`+aapl+`","amount":"7.30","rate":"0.7300000000","position":"10.0000","withholding":"0.00","record_date":"2018-05-14","payable_date":"2018-05-17","paid_at":"2018-05-18T02:17:04Z","state":"paid"},`+
+		`{"id":"d2","account":"https://api.robinhood.com/accounts/account/","instrument":"`+gone+`","amount":"0.30","rate":"0.1000000000","position":"3.0000","withholding":"0.00","record_date":"2018-05-14","payable_date":"2018-05-17","paid_at":"2018-05-18T02:17:04Z","state":"paid"}`+
+		`],"next":null}`))
+
+	c := Client{AccountID: "account", Token: "token"}
+	projections, err := c.ProjectedIncome(time.Date(2018, 6, 22, 0, 0, 0, 0, time.UTC))
+	if _, ok := err.(*UnresolvedPositionsError); !ok {
+		t.Fatalf("err = %v, want *UnresolvedPositionsError", err)
+	}
+	if len(projections) != 2 {
+		t.Fatalf("len(projections) = %d, want 2", len(projections))
+	}
+	if p := projections[0]; p.Symbol != "AAPL" || math.Abs(p.Income-7.3) > 1e-9 {
+		t.Errorf("AAPL = %+v", p)
+	}
+	if p := projections[1]; p.Symbol != "" || math.Abs(p.Income-0.3) > 1e-9 || p.Yield != 0 {
+		t.Errorf("unresolved = %+v", p)
+	}
+}
@@ -27,17 +27,6 @@ func (c *Client) Expirations(symbol string) ([]time.Time, error) {
 	return exps, nil
 }
 
-func (c *Client) getInstrumentID(symbol string) (string, error) {
-	quotes, err := c.quote([]string{symbol})
-	if err != nil {
-		return "", err
-	}
-	if len(quotes) != 1 {
-		return "", fmt.Errorf("expected one quote for symbol %s, got %d", symbol, len(quotes))
-	}
-	return quotes[0].Instrument.GetID(), nil
-}
-
 type expirations struct {
 	ID          string   `json:"id"`
 	Symbol      string   `json:"symbol"`
@@ -46,12 +35,19 @@ type expirations struct {
 
 const dateFormat = "2006-01-02"
 
+// expirations returns the option chain of symbol, with its ID and expiration
+// dates. Results are cached.
 func (c *Client) expirations(symbol string) (expirations, error) {
 	var e0 expirations
-	instrumentID, err := c.getInstrumentID(symbol)
+	c.init()
+	if v, ok := c.optionChains.get(symbol); ok {
+		return v.(expirations), nil
+	}
+	inst, err := c.InstrumentBySymbol(symbol)
 	if err != nil {
 		return e0, err
 	}
+	instrumentID := inst.ID
 	resp, err := c.paginatedGet(chainsURI + "?equity_instrument_ids=" + instrumentID)
 	if err != nil {
 		return e0, err
@@ -65,6 +61,7 @@ func (c *Client) expirations(symbol string) (expirations, error) {
 	// 2SPY, SPY and 1GOOG, 2GOOG, GOOG, etc.
 	for _, e := range exp {
 		if e.Symbol == symbol {
+			c.optionChains.set(symbol, e)
 			return e, nil
 		}
 	}
@@ -0,0 +1,153 @@
+package robinhood
+
+// This file deals with fundamentals and company profile data.
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// Fundamentals holds valuation, trading and profile data for a security.
+// Numeric fields that the server does not report (e.g. the P/E ratio of a
+// company with no earnings or the dividend yield of an ETF) are zero.
+type Fundamentals struct {
+	Symbol string
+
+	Open          float64
+	High          float64
+	Low           float64
+	Volume        float64
+	AverageVolume float64
+	High52Weeks   float64
+	Low52Weeks    float64
+	MarketCap     float64
+	PERatio       float64
+	DividendYield float64 // In percent, e.g. 1.85 means 1.85%.
+
+	SharesOutstanding float64
+
+	Sector       string
+	Industry     string
+	CEO          string
+	Description  string
+	Headquarters string // "City, State", when known.
+	NumEmployees int64
+	YearFounded  int64
+	instrument   Instrument
+}
+
+// Fundamentals returns fundamentals for the requested security symbols, in the
+// same order as requested. Like Quote, it does not work on option symbols,
+// splits long lists of symbols into several requests and returns an
+// *UnknownSymbolsError along with the other results if some symbols are
+// unknown.
+func (c *Client) Fundamentals(symbol []string) ([]Fundamentals, error) {
+	funds, err := c.fundamentals(symbol)
+	if err != nil {
+		return nil, err
+	}
+	var fs []Fundamentals
+	var missing []int
+	for i, f := range funds {
+		if f == nil {
+			missing = append(missing, i)
+			continue
+		}
+		open, err := parseOptionalFloat64(f.Open, nil)
+		high, err := parseOptionalFloat64(f.High, err)
+		low, err := parseOptionalFloat64(f.Low, err)
+		volume, err := parseOptionalFloat64(f.Volume, err)
+		avgVolume, err := parseOptionalFloat64(f.AverageVolume, err)
+		high52, err := parseOptionalFloat64(f.High52Weeks, err)
+		low52, err := parseOptionalFloat64(f.Low52Weeks, err)
+		marketCap, err := parseOptionalFloat64(f.MarketCap, err)
+		pe, err := parseOptionalFloat64(f.PERatio, err)
+		divYield, err := parseOptionalFloat64(f.DividendYield, err)
+		shares, err := parseOptionalFloat64(f.SharesOutstanding, err)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing fundamentals for %q: %v", symbol[i], err)
+		}
+		var hq []string
+		for _, s := range []string{f.HeadquartersCity, f.HeadquartersState} {
+			if s != "" {
+				hq = append(hq, s)
+			}
+		}
+		fs = append(fs, Fundamentals{
+			Symbol:            strings.ToUpper(symbol[i]),
+			Open:              open,
+			High:              high,
+			Low:               low,
+			Volume:            volume,
+			AverageVolume:     avgVolume,
+			High52Weeks:       high52,
+			Low52Weeks:        low52,
+			MarketCap:         marketCap,
+			PERatio:           pe,
+			DividendYield:     divYield,
+			SharesOutstanding: shares,
+			Sector:            f.Sector,
+			Industry:          f.Industry,
+			CEO:               f.CEO,
+			Description:       f.Description,
+			Headquarters:      strings.Join(hq, ", "),
+			NumEmployees:      f.NumEmployees,
+			YearFounded:       f.YearFounded,
+			instrument:        f.Instrument,
+		})
+	}
+	return fs, unknownSymbols(symbol, missing)
+}
+
+type fundamentals struct {
+	Open              string     `json:"open"`
+	High              string     `json:"high"`
+	Low               string     `json:"low"`
+	Volume            string     `json:"volume"`
+	AverageVolume     string     `json:"average_volume"`
+	High52Weeks       string     `json:"high_52_weeks"`
+	Low52Weeks        string     `json:"low_52_weeks"`
+	MarketCap         string     `json:"market_cap"`
+	PERatio           string     `json:"pe_ratio"`
+	DividendYield     string     `json:"dividend_yield"`
+	SharesOutstanding string     `json:"shares_outstanding"`
+	Sector            string     `json:"sector"`
+	Industry          string     `json:"industry"`
+	CEO               string     `json:"ceo"`
+	Description       string     `json:"description"`
+	HeadquartersCity  string     `json:"headquarters_city"`
+	HeadquartersState string     `json:"headquarters_state"`
+	NumEmployees      int64      `json:"num_employees"`
+	YearFounded       int64      `json:"year_founded"`
+	Instrument        Instrument `json:"instrument"`
+}
+
+// fundamentals fetches fundamentals in the same order as the symbols. Symbols
+// unknown to the server are nil.
+func (c *Client) fundamentals(symbol []string) ([]*fundamentals, error) {
+	funds := make([]*fundamentals, len(symbol))
+	err := forEachBatch(len(symbol), maxSymbolsPerRequest, func(start, end int) error {
+		resp, err := c.get(fundamentalsURI + "?symbols=" + strings.Join(symbol[start:end], ","))
+		if err != nil {
+			return err
+		}
+		var results map[string][]*fundamentals
+		err = json.Unmarshal(resp, &results)
+		if err != nil {
+			return err
+		}
+		// Fundamentals don't carry a symbol, so rely on the server returning
+		// them in the order requested, with null for unknown symbols.
+		res := results["results"]
+		if len(res) != end-start {
+			return fmt.Errorf("expected %d fundamentals, got %d", end-start, len(res))
+		}
+		copy(funds[start:end], res)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return funds, nil
+}
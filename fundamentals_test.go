@@ -0,0 +1,52 @@
+package robinhood
+
+import (
+	"testing"
+
+	httpmock "gopkg.in/jarcoal/httpmock.v1"
+)
+
+var fundamentalsReplies = map[string]string{
+	apiURL + fundamentalsURI + "?symbols=SPY,AAPL": `{"results":[{"open":"271.6000","high":"272.0300","low":"269.6000","volume":"23158014.0000","average_volume_2_weeks":"73549383.5000","average_volume":"73549383.5000","high_52_weeks":"286.6300","dividend_yield":"1.8549","low_52_weeks":"241.1800","market_cap":"","pe_ratio":null,"shares_outstanding":null,"description":"SPDR S&P 500 ETF Trust is an exchange-traded fund.","instrument":"https://api.robinhood.com/instruments/8f92e76f-1e0e-4478-8580-16a6ffcfaef5/","ceo":"","headquarters_city":"","headquarters_state":"","sector":"Miscellaneous","industry":"Investment Trusts Or Mutual Funds","num_employees":null,"year_founded":null},{"open":"184.1700","high":"186.0500","low":"183.5500","volume":"17216394.0000","average_volume":"24157282.6000","high_52_weeks":"193.9800","dividend_yield":"1.4852","low_52_weeks":"142.2000","market_cap":"908940000000.0000","pe_ratio":"18.9000","shares_outstanding":"4915138000.0000","description":"Apple, Inc. engages in the design of consumer electronics.","instrument":"https://api.robinhood.com/instruments/450dfc6d-5510-4d40-abfb-f633b7d9be3e/","ceo":"Timothy Donald Cook","headquarters_city":"Cupertino","headquarters_state":"California","sector":"Electronic Technology","industry":"Telecommunications Equipment","num_employees":123000,"year_founded":1976}]}`,
+	apiURL + fundamentalsURI + "?symbols=SPY,NOPE": `{"results":[{"open":"271.6000"},null]}`,
+}
+
+func TestFundamentals(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	for url, reply := range fundamentalsReplies {
+		httpmock.RegisterResponder("GET", url, httpmock.NewStringResponder(200, reply))
+	}
+
+	c := Client{
+		AccountID: "account",
+		Token:     "token",
+	}
+	got, err := c.Fundamentals([]string{"SPY", "AAPL"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(got) != 2 {
+		t.Fatalf("len(got) = %d, want 2", len(got))
+	}
+	spy, aapl := got[0], got[1]
+	if spy.Symbol != "SPY" || spy.High52Weeks != 286.63 || spy.DividendYield != 1.8549 || spy.MarketCap != 0 || spy.PERatio != 0 || spy.CEO != "" || spy.Headquarters != "" {
+		t.Errorf("unexpected SPY fundamentals: %+v", spy)
+	}
+	if aapl.Symbol != "AAPL" || aapl.MarketCap != 908940000000 || aapl.PERatio != 18.9 || aapl.AverageVolume != 24157282.6 || aapl.Low52Weeks != 142.2 {
+		t.Errorf("unexpected AAPL numbers: %+v", aapl)
+	}
+	if aapl.CEO != "Timothy Donald Cook" || aapl.Sector != "Electronic Technology" || aapl.Headquarters != "Cupertino, California" || aapl.NumEmployees != 123000 || aapl.YearFounded != 1976 {
+		t.Errorf("unexpected AAPL profile: %+v", aapl)
+	}
+
+	got, err = c.Fundamentals([]string{"SPY", "NOPE"})
+	unknown, ok := err.(*UnknownSymbolsError)
+	if !ok || len(unknown.Symbols) != 1 || unknown.Symbols[0] != "NOPE" {
+		t.Fatalf("err = %v, want unknown symbol NOPE", err)
+	}
+	if len(got) != 1 || got[0].Symbol != "SPY" || got[0].Open != 271.6 {
+		t.Errorf("unexpected partial results: %+v", got)
+	}
+}
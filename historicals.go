@@ -0,0 +1,162 @@
+package robinhood
+
+import (
+	"encoding/json"
+	"net/url"
+	"time"
+)
+
+// This file deals with the history of the value of an account.
+
+// Span is the period of time an equity history covers, ending now.
+type Span int
+
+// Spans of equity history.
+const (
+	SpanDay Span = iota + 1
+	SpanWeek
+	SpanYear
+	Span5Years
+	SpanAll
+)
+
+// String returns the span as the API names it, e.g. "week".
+func (s Span) String() string {
+	switch s {
+	case SpanDay:
+		return "day"
+	case SpanWeek:
+		return "week"
+	case SpanYear:
+		return "year"
+	case Span5Years:
+		return "5year"
+	case SpanAll:
+		return "all"
+	default:
+		return "invalid span"
+	}
+}
+
+// Interval is the time between points of an equity history.
+type Interval int
+
+// Intervals of equity history. Not all combinations with Span are allowed;
+// e.g. SpanDay requires Interval5Minutes or Interval10Minutes.
+const (
+	Interval5Minutes Interval = iota + 1
+	Interval10Minutes
+	IntervalHour
+	IntervalDay
+	IntervalWeek
+)
+
+// String returns the interval as the API names it, e.g. "5minute".
+func (i Interval) String() string {
+	switch i {
+	case Interval5Minutes:
+		return "5minute"
+	case Interval10Minutes:
+		return "10minute"
+	case IntervalHour:
+		return "hour"
+	case IntervalDay:
+		return "day"
+	case IntervalWeek:
+		return "week"
+	default:
+		return "invalid interval"
+	}
+}
+
+// EquityPoint is the value of an account over one interval.
+type EquityPoint struct {
+	Time time.Time // Start of the interval.
+
+	OpenEquity  float64
+	CloseEquity float64
+	// AdjustedOpenEquity and AdjustedCloseEquity are adjusted for deposits
+	// and withdrawals.
+	AdjustedOpenEquity  float64
+	AdjustedCloseEquity float64
+	OpenMarketValue     float64 // Of all positions.
+	CloseMarketValue    float64
+	NetReturn           float64
+
+	// Session is the trading session the point is in: "pre", "reg" or
+	// "post". Empty for intervals of a day or longer.
+	Session string
+}
+
+// EquityHistory is the value of an account over time.
+type EquityHistory struct {
+	Span     Span
+	Interval Interval
+	Points   []EquityPoint // Oldest first.
+	// TotalReturn is the return over the span, as reported by the server.
+	TotalReturn float64
+}
+
+// PortfolioHistory returns the value of the client's account, AccountID, over
+// the span, with a point per interval.
+func (c *Client) PortfolioHistory(span Span, interval Interval) (EquityHistory, error) {
+	parms := url.Values{}
+	parms.Set("span", span.String())
+	parms.Set("interval", interval.String())
+	resp, err := c.get(historicalsURI + c.AccountID + "/?" + parms.Encode())
+	if err != nil {
+		return EquityHistory{}, err
+	}
+	var h equityHistory
+	err = json.Unmarshal(resp, &h)
+	if err != nil {
+		return EquityHistory{}, err
+	}
+	total, err := parseOptionalFloat64(h.TotalReturn, nil)
+	if err != nil {
+		return EquityHistory{}, err
+	}
+	history := EquityHistory{Span: span, Interval: interval, TotalReturn: total}
+	for _, p := range h.Points {
+		begins, err := parseTime(p.BeginsAt, nil)
+		openEquity, err := parseOptionalFloat64(p.OpenEquity, err)
+		closeEquity, err := parseOptionalFloat64(p.CloseEquity, err)
+		adjOpen, err := parseOptionalFloat64(p.AdjustedOpenEquity, err)
+		adjClose, err := parseOptionalFloat64(p.AdjustedCloseEquity, err)
+		openMV, err := parseOptionalFloat64(p.OpenMarketValue, err)
+		closeMV, err := parseOptionalFloat64(p.CloseMarketValue, err)
+		netReturn, err := parseOptionalFloat64(p.NetReturn, err)
+		if err != nil {
+			return EquityHistory{}, err
+		}
+		history.Points = append(history.Points, EquityPoint{
+			Time:                begins,
+			OpenEquity:          openEquity,
+			CloseEquity:         closeEquity,
+			AdjustedOpenEquity:  adjOpen,
+			AdjustedCloseEquity: adjClose,
+			OpenMarketValue:     openMV,
+			CloseMarketValue:    closeMV,
+			NetReturn:           netReturn,
+			Session:             p.Session,
+		})
+	}
+	return history, nil
+}
+
+type equityHistory struct {
+	Points      []equityPoint `json:"equity_historicals"`
+	TotalReturn string        `json:"total_return"`
+}
+
+type equityPoint struct {
+	BeginsAt            string `json:"begins_at"`
+	OpenEquity          string `json:"open_equity"`
+	CloseEquity         string `json:"close_equity"`
+	AdjustedOpenEquity  string `json:"adjusted_open_equity"`
+	AdjustedCloseEquity string `json:"adjusted_close_equity"`
+	OpenMarketValue     string `json:"open_market_value"`
+	CloseMarketValue    string `json:"close_market_value"`
+	NetReturn           string `json:"net_return"`
+	Session             string `json:"session"`
+}
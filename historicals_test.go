@@ -0,0 +1,42 @@
+package robinhood
+
+import (
+	"testing"
+	"time"
+
+	httpmock "gopkg.in/jarcoal/httpmock.v1"
+)
+
+func TestPortfolioHistory(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", apiURL+historicalsURI+"5RY82436/?interval=day&span=week", httpmock.NewStringResponder(200,
+		`{"adjusted_open_equity":"10000.0000","bounds":"regular","total_return":"0.0250","equity_historicals":[`+
+			`{"adjusted_close_equity":"10100.0000","begins_at":"2018-06-18T00:00:00Z","open_market_value":"7000.0000","session":"reg","adjusted_open_equity":"10000.0000","close_market_value":"7100.0000","net_return":"0.0100","open_equity":"10000.0000","close_equity":"10100.0000"},`+
+			`{"adjusted_close_equity":"10250.0000","begins_at":"2018-06-19T00:00:00Z","open_market_value":"7100.0000","session":"reg","adjusted_open_equity":"10100.0000","close_market_value":"7250.0000","net_return":"0.0149","open_equity":"10100.0000","close_equity":"10250.0000"}`+
+			`],"open_time":"2018-06-18T13:30:00Z","interval":"day","span":"week","previous_close_equity":"10000.0000"}`))
+
+	c := Client{AccountID: "5RY82436", Token: "token"}
+	h, err := c.PortfolioHistory(SpanWeek, IntervalDay)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if h.Span != SpanWeek || h.Interval != IntervalDay || h.TotalReturn != 0.025 || len(h.Points) != 2 {
+		t.Fatalf("history = %+v", h)
+	}
+	want := EquityPoint{
+		Time:                time.Date(2018, 6, 19, 0, 0, 0, 0, time.UTC),
+		OpenEquity:          10100,
+		CloseEquity:         10250,
+		AdjustedOpenEquity:  10100,
+		AdjustedCloseEquity: 10250,
+		OpenMarketValue:     7100,
+		CloseMarketValue:    7250,
+		NetReturn:           0.0149,
+		Session:             "reg",
+	}
+	if got := h.Points[1]; got != want {
+		t.Errorf("Points[1] = %+v, want %+v", got, want)
+	}
+}
@@ -1,6 +1,13 @@
 package robinhood
 
-import "strings"
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
 
 // Instrument represents a Robinhood resource.
 type Instrument string
@@ -13,3 +20,283 @@ func (i Instrument) GetID() string {
 	}
 	return fields[len(fields)-2]
 }
+
+// InstrumentInfo describes a tradable security, such as a stock or an ETF.
+type InstrumentInfo struct {
+	ID     string
+	URL    Instrument
+	Symbol string
+	Name   string
+	Type   string // "stock", "etp", "adr", etc.
+	State  string // "active", "inactive", etc.
+
+	// Tradeable is true if the instrument can currently be traded at all.
+	// Tradability further details how, e.g. "tradable" or
+	// "position_closing_only".
+	Tradeable   bool
+	Tradability string
+
+	// Market is the MIC of the listing exchange, e.g. "XNAS" or "ARCX".
+	Market   string
+	Country  string
+	ListDate time.Time // Zero if unknown.
+
+	MarginInitialRatio float64
+	MaintenanceRatio   float64
+	DayTradeRatio      float64
+	MinTickSize        float64 // Zero if the exchange's default tick applies.
+}
+
+// Split is a stock split (or reverse split) of an instrument. A 2-for-1 split
+// has a Multiplier of 2 and a Divisor of 1.
+type Split struct {
+	ExecutionDate time.Time
+	Multiplier    float64
+	Divisor       float64
+}
+
+// Ratio returns the number of new shares for each old share.
+func (s Split) Ratio() float64 {
+	if s.Divisor == 0 {
+		return 0
+	}
+	return s.Multiplier / s.Divisor
+}
+
+// tradable returns whether the instrument can be traded without restriction,
+// to open positions as well as close them.
+func (i InstrumentInfo) tradable() bool {
+	if i.Tradability == "" {
+		return i.Tradeable
+	}
+	return i.Tradability == "tradable"
+}
+
+// InstrumentBySymbol returns the instrument for a security symbol.
+func (c *Client) InstrumentBySymbol(symbol string) (InstrumentInfo, error) {
+	c.init()
+	symbol = strings.ToUpper(symbol)
+	if v, ok := c.instruments.get("symbol:" + symbol); ok {
+		return v.(InstrumentInfo), nil
+	}
+	insts, err := c.searchInstruments("symbol", symbol)
+	if err != nil {
+		return InstrumentInfo{}, err
+	}
+	for _, i := range insts {
+		if i.Symbol == symbol {
+			return i, nil
+		}
+	}
+	return InstrumentInfo{}, fmt.Errorf("no instrument found for symbol %q", symbol)
+}
+
+// InstrumentByID returns the instrument with the given ID.
+func (c *Client) InstrumentByID(id string) (InstrumentInfo, error) {
+	c.init()
+	if v, ok := c.instruments.get("id:" + id); ok {
+		return v.(InstrumentInfo), nil
+	}
+	resp, err := c.get(instrumentsURI + id + "/")
+	if err != nil {
+		return InstrumentInfo{}, err
+	}
+	var i instrument
+	err = json.Unmarshal(resp, &i)
+	if err != nil {
+		return InstrumentInfo{}, err
+	}
+	return c.cacheInstrument(i)
+}
+
+// InstrumentByURL returns the instrument a resource URL, such as the one in a
+// position or an order, refers to.
+func (c *Client) InstrumentByURL(u Instrument) (InstrumentInfo, error) {
+	id := u.GetID()
+	if id == "" {
+		return InstrumentInfo{}, fmt.Errorf("invalid instrument URL %q", u)
+	}
+	return c.InstrumentByID(id)
+}
+
+// resolveInstruments resolves many instrument URLs concurrently through the
+// cache, fetching each distinct URL once. The result is aligned to urls;
+// instruments that can't be resolved are left zero and listed once each in
+// the returned error, if any.
+func (c *Client) resolveInstruments(urls []Instrument) ([]InstrumentInfo, *UnresolvedInstrumentsError) {
+	index := make(map[Instrument]int)
+	var unique []Instrument
+	for _, u := range urls {
+		if _, ok := index[u]; !ok {
+			index[u] = len(unique)
+			unique = append(unique, u)
+		}
+	}
+	resolved := make([]InstrumentInfo, len(unique))
+	errs := make([]error, len(unique))
+	c.forEach(len(unique), func(i int) error {
+		resolved[i], errs[i] = c.InstrumentByURL(unique[i])
+		return nil
+	})
+	insts := make([]InstrumentInfo, len(urls))
+	for i, u := range urls {
+		insts[i] = resolved[index[u]]
+	}
+	var unresolved *UnresolvedInstrumentsError
+	for i, err := range errs {
+		if err == nil {
+			continue
+		}
+		if unresolved == nil {
+			unresolved = &UnresolvedInstrumentsError{}
+		}
+		unresolved.Instruments = append(unresolved.Instruments, unique[i])
+		unresolved.Errs = append(unresolved.Errs, err)
+	}
+	return insts, unresolved
+}
+
+// UnresolvedInstrumentsError is returned, along with partial results, when
+// some instruments referred to by the results can't be resolved.
+type UnresolvedInstrumentsError struct {
+	Instruments []Instrument
+	Errs        []error // Errs[i] is the error resolving Instruments[i].
+}
+
+// Error implements error.
+func (e *UnresolvedInstrumentsError) Error() string {
+	return fmt.Sprintf("could not resolve %d instruments: %v", len(e.Instruments), e.Errs[0])
+}
+
+// SearchInstruments returns the instruments matching a keyword, such as part
+// of a company name.
+func (c *Client) SearchInstruments(query string) ([]InstrumentInfo, error) {
+	return c.searchInstruments("query", query)
+}
+
+// Splits returns the splits of the instrument with the given symbol, oldest
+// first.
+func (c *Client) Splits(symbol string) ([]Split, error) {
+	inst, err := c.InstrumentBySymbol(symbol)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.paginatedGet(instrumentsURI + inst.ID + "/splits/")
+	if err != nil {
+		return nil, err
+	}
+	var ss []split
+	err = json.Unmarshal(resp, &ss)
+	if err != nil {
+		return nil, err
+	}
+	var splits []Split
+	for _, s := range ss {
+		date, err := time.Parse(dateFormat, s.ExecutionDate)
+		mult, err := parseFloat64(s.Multiplier, err)
+		div, err := parseFloat64(s.Divisor, err)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing split of %s: %v", symbol, err)
+		}
+		splits = append(splits, Split{
+			ExecutionDate: date,
+			Multiplier:    mult,
+			Divisor:       div,
+		})
+	}
+	sort.Slice(splits, func(i, j int) bool {
+		return splits[i].ExecutionDate.Before(splits[j].ExecutionDate)
+	})
+	return splits, nil
+}
+
+type instrument struct {
+	ID                 string     `json:"id"`
+	URL                Instrument `json:"url"`
+	Symbol             string     `json:"symbol"`
+	Name               string     `json:"name"`
+	SimpleName         string     `json:"simple_name"`
+	Type               string     `json:"type"`
+	State              string     `json:"state"`
+	Tradeable          bool       `json:"tradeable"`
+	Tradability        string     `json:"tradability"`
+	Market             Instrument `json:"market"`
+	Country            string     `json:"country"`
+	ListDate           string     `json:"list_date"`
+	MarginInitialRatio string     `json:"margin_initial_ratio"`
+	MaintenanceRatio   string     `json:"maintenance_ratio"`
+	DayTradeRatio      string     `json:"day_trade_ratio"`
+	MinTickSize        string     `json:"min_tick_size"`
+}
+
+type split struct {
+	ExecutionDate string `json:"execution_date"`
+	Multiplier    string `json:"multiplier"`
+	Divisor       string `json:"divisor"`
+}
+
+// searchInstruments queries the instruments endpoint with a single parameter
+// and caches all instruments found.
+func (c *Client) searchInstruments(key, value string) ([]InstrumentInfo, error) {
+	c.init()
+	parms := url.Values{}
+	parms.Set(key, value)
+	resp, err := c.paginatedGet(instrumentsURI + "?" + parms.Encode())
+	if err != nil {
+		return nil, err
+	}
+	var is []instrument
+	err = json.Unmarshal(resp, &is)
+	if err != nil {
+		return nil, err
+	}
+	var insts []InstrumentInfo
+	for _, i := range is {
+		inst, err := c.cacheInstrument(i)
+		if err != nil {
+			return nil, err
+		}
+		insts = append(insts, inst)
+	}
+	return insts, nil
+}
+
+// cacheInstrument converts i to its external format and caches it by ID and
+// by symbol.
+func (c *Client) cacheInstrument(i instrument) (InstrumentInfo, error) {
+	initial, err := parseOptionalFloat64(i.MarginInitialRatio, nil)
+	maintenance, err := parseOptionalFloat64(i.MaintenanceRatio, err)
+	dayTrade, err := parseOptionalFloat64(i.DayTradeRatio, err)
+	tick, err := parseOptionalFloat64(i.MinTickSize, err)
+	var listDate time.Time
+	if i.ListDate != "" && err == nil {
+		listDate, err = time.Parse(dateFormat, i.ListDate)
+	}
+	if err != nil {
+		return InstrumentInfo{}, fmt.Errorf("error parsing instrument %s: %v", i.Symbol, err)
+	}
+	name := i.SimpleName
+	if name == "" {
+		name = i.Name
+	}
+	inst := InstrumentInfo{
+		ID:                 i.ID,
+		URL:                i.URL,
+		Symbol:             i.Symbol,
+		Name:               name,
+		Type:               i.Type,
+		State:              i.State,
+		Tradeable:          i.Tradeable,
+		Tradability:        i.Tradability,
+		Market:             i.Market.GetID(),
+		Country:            i.Country,
+		ListDate:           listDate,
+		MarginInitialRatio: initial,
+		MaintenanceRatio:   maintenance,
+		DayTradeRatio:      dayTrade,
+		MinTickSize:        tick,
+	}
+	c.instruments.set("id:"+inst.ID, inst)
+	c.instruments.set("symbol:"+inst.Symbol, inst)
+	return inst, nil
+}
@@ -1,6 +1,12 @@
 package robinhood
 
-import "testing"
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	httpmock "gopkg.in/jarcoal/httpmock.v1"
+)
 
 func TestGetID(t *testing.T) {
 	i := Instrument("https://api.robinhood.com/instruments/8f92e76f-1e0e-4478-8580-16a6ffcfaef5/")
@@ -15,3 +21,96 @@ func TestFail(t *testing.T) {
 		t.Fatalf(`expected "", got %q`, i.GetID())
 	}
 }
+
+var instrumentReplies = map[string]string{
+	apiURL + instrumentsURI + "?symbol=AAPL":                                 `{"previous":null,"results":[{"margin_initial_ratio":"0.5000","rhs_tradability":"tradable","id":"450dfc6d-5510-4d40-abfb-f633b7d9be3e","market":"https://api.robinhood.com/markets/XNAS/","simple_name":"Apple","min_tick_size":null,"maintenance_ratio":"0.2500","tradability":"tradable","state":"active","type":"stock","tradeable":true,"symbol":"AAPL","day_trade_ratio":"0.2500","name":"Apple Inc. - Common Stock","splits":"https://api.robinhood.com/instruments/450dfc6d-5510-4d40-abfb-f633b7d9be3e/splits/","url":"https://api.robinhood.com/instruments/450dfc6d-5510-4d40-abfb-f633b7d9be3e/","country":"US","list_date":"1990-01-02"}],"next":null}`,
+	apiURL + instrumentsURI + "ebab2398-028d-4939-9f1d-13bf38f81c50/":        `{"margin_initial_ratio":"1.0000","id":"ebab2398-028d-4939-9f1d-13bf38f81c50","market":"https://api.robinhood.com/markets/XNYS/","simple_name":null,"min_tick_size":"0.0100","maintenance_ratio":"1.0000","tradability":"position_closing_only","state":"active","type":"stock","tradeable":false,"symbol":"FB","day_trade_ratio":"0.2500","name":"Facebook, Inc. - Class A Common Stock","url":"https://api.robinhood.com/instruments/ebab2398-028d-4939-9f1d-13bf38f81c50/","country":"US","list_date":null}`,
+	apiURL + instrumentsURI + "?query=apple":                                 `{"previous":null,"results":[{"id":"450dfc6d-5510-4d40-abfb-f633b7d9be3e","symbol":"AAPL","simple_name":"Apple","tradeable":true,"url":"https://api.robinhood.com/instruments/450dfc6d-5510-4d40-abfb-f633b7d9be3e/"},{"id":"0a8a072c-e52c-4e41-a2ee-8adbd72217d3","symbol":"APLE","simple_name":"Apple Hospitality REIT","tradeable":true,"url":"https://api.robinhood.com/instruments/0a8a072c-e52c-4e41-a2ee-8adbd72217d3/"}],"next":null}`,
+	apiURL + instrumentsURI + "450dfc6d-5510-4d40-abfb-f633b7d9be3e/splits/": `{"previous":null,"results":[{"url":"https://api.robinhood.com/instruments/450dfc6d-5510-4d40-abfb-f633b7d9be3e/splits/2/","instrument":"https://api.robinhood.com/instruments/450dfc6d-5510-4d40-abfb-f633b7d9be3e/","execution_date":"2014-06-09","multiplier":"7.00000000","divisor":"1.00000000"},{"url":"https://api.robinhood.com/instruments/450dfc6d-5510-4d40-abfb-f633b7d9be3e/splits/1/","instrument":"https://api.robinhood.com/instruments/450dfc6d-5510-4d40-abfb-f633b7d9be3e/","execution_date":"2005-02-28","multiplier":"2.00000000","divisor":"1.00000000"}],"next":null}`,
+}
+
+func TestInstruments(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	calls := 0
+	for url, reply := range instrumentReplies {
+		reply := reply
+		httpmock.RegisterResponder("GET", url, func(req *http.Request) (*http.Response, error) {
+			calls++
+			return httpmock.NewStringResponse(200, reply), nil
+		})
+	}
+
+	c := Client{Token: "token"}
+	aapl, err := c.InstrumentBySymbol("aapl")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if aapl.ID != "450dfc6d-5510-4d40-abfb-f633b7d9be3e" || aapl.Name != "Apple" || aapl.Market != "XNAS" || !aapl.Tradeable || aapl.MarginInitialRatio != 0.5 || aapl.MinTickSize != 0 {
+		t.Errorf("unexpected AAPL instrument: %+v", aapl)
+	}
+	if want := time.Date(1990, 1, 2, 0, 0, 0, 0, time.UTC); !aapl.ListDate.Equal(want) {
+		t.Errorf("ListDate = %v, want %v", aapl.ListDate, want)
+	}
+	// Lookups by ID, by URL and by symbol again are all served from the cache.
+	byURL, err := c.InstrumentByURL(aapl.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	byID, err := c.InstrumentByID(aapl.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.InstrumentBySymbol("AAPL"); err != nil {
+		t.Fatal(err)
+	}
+	if byURL != aapl || byID != aapl {
+		t.Errorf("cached instruments differ: %+v, %+v", byURL, byID)
+	}
+	if calls != 1 {
+		t.Errorf("calls = %d, want 1", calls)
+	}
+
+	fb, err := c.InstrumentByURL("https://api.robinhood.com/instruments/ebab2398-028d-4939-9f1d-13bf38f81c50/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if fb.Symbol != "FB" || fb.Name != "Facebook, Inc. - Class A Common Stock" || fb.Tradeable || fb.Tradability != "position_closing_only" || fb.MinTickSize != 0.01 || !fb.ListDate.IsZero() {
+		t.Errorf("unexpected FB instrument: %+v", fb)
+	}
+	if _, err := c.InstrumentBySymbol("FB"); err != nil {
+		t.Fatal(err)
+	}
+
+	found, err := c.SearchInstruments("apple")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(found) != 2 || found[0].Symbol != "AAPL" || found[1].Symbol != "APLE" {
+		t.Errorf("unexpected search results: %+v", found)
+	}
+
+	splits, err := c.Splits("AAPL")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(splits) != 2 || splits[0].Ratio() != 2 || splits[1].Ratio() != 7 || splits[1].ExecutionDate.Year() != 2014 {
+		t.Errorf("unexpected splits: %+v", splits)
+	}
+	if calls != 4 {
+		t.Errorf("calls = %d, want 4", calls)
+	}
+}
+
+func TestCacheExpiration(t *testing.T) {
+	tc := newTTLCache(time.Millisecond)
+	tc.set("key", 1)
+	if v, ok := tc.get("key"); !ok || v.(int) != 1 {
+		t.Fatalf("get = %v, %v; want 1, true", v, ok)
+	}
+	time.Sleep(5 * time.Millisecond)
+	if _, ok := tc.get("key"); ok {
+		t.Fatal("expected entry to expire")
+	}
+}
@@ -0,0 +1,217 @@
+package ledger
+
+// This file exports activities as CSV, JSON, OFX and QIF.
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+// WriteCSV writes one row per activity.
+func WriteCSV(w io.Writer, acts []Activity) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"time", "type", "id", "symbol", "description", "quantity", "price", "amount", "state"})
+	for _, a := range acts {
+		cw.Write([]string{
+			a.Time.Format(time.RFC3339),
+			a.Type.String(),
+			a.ID,
+			a.Symbol,
+			a.Description,
+			strconv.FormatFloat(a.Quantity, 'f', -1, 64),
+			strconv.FormatFloat(a.Price, 'f', -1, 64),
+			formatAmount(a.Amount),
+			a.State,
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteJSON writes the activities as a JSON array.
+func WriteJSON(w io.Writer, acts []Activity) error {
+	if acts == nil {
+		acts = []Activity{}
+	}
+	return json.NewEncoder(w).Encode(acts)
+}
+
+// WriteQIF writes the activities that moved cash as a QIF bank register, for
+// accounting software that tracks the account's cash.
+func WriteQIF(w io.Writer, acts []Activity) error {
+	_, err := fmt.Fprintln(w, "!Type:Bank")
+	for _, a := range acts {
+		if a.Amount == 0 || err != nil {
+			continue
+		}
+		_, err = fmt.Fprintf(w, "D%s\nT%s\nP%s\nM%s %s\n^\n",
+			a.Time.Format("01/02/2006"), formatAmount(a.Amount), a.Description, a.Type, a.ID)
+	}
+	return err
+}
+
+// Statement describes the account an OFX export is for.
+type Statement struct {
+	Account string  // Account number.
+	Balance float64 // Cash in the account at AsOf.
+	// AsOf is when Balance was read, e.g. time.Now() for Account().Cash.
+	// Defaults to the time of the last activity.
+	AsOf time.Time
+}
+
+// WriteOFX writes the activities that moved cash as an OFX 2.2 bank
+// statement, of type CHECKING, of the account's cash only. It is not an
+// investment statement: holdings are left out, and fills appear only as the
+// cash they moved, for accounting software that tracks the brokerage account
+// as a bank account.
+func WriteOFX(w io.Writer, st Statement, acts []Activity) error {
+	var start, end time.Time
+	var trans []ofxTransaction
+	for _, a := range acts {
+		if start.IsZero() || a.Time.Before(start) {
+			start = a.Time
+		}
+		if a.Time.After(end) {
+			end = a.Time
+		}
+		if a.Amount == 0 {
+			continue
+		}
+		trans = append(trans, ofxTransaction{
+			Type:   ofxType(a),
+			Posted: formatOFXTime(a.Time),
+			Amount: formatAmount(a.Amount),
+			FITID:  a.ID,
+			Name:   truncate(a.Description, 32),
+			Memo:   a.Description,
+		})
+	}
+	asOf := st.AsOf
+	if asOf.IsZero() {
+		asOf = end
+	}
+	ok := ofxStatus{Code: 0, Severity: "INFO"}
+	doc := ofx{
+		SignOn: ofxSignOn{Status: ok, Server: formatOFXTime(asOf), Language: "ENG"},
+		Bank: ofxBank{
+			TrnUID:   "0",
+			Status:   ok,
+			Currency: "USD",
+			Account: ofxAccount{
+				BankID: "ROBINHOOD",
+				ID:     st.Account,
+				Type:   "CHECKING",
+			},
+			List: ofxTransactionList{
+				Start:        formatOFXTime(start),
+				End:          formatOFXTime(end),
+				Transactions: trans,
+			},
+			Balance: ofxBalance{Amount: formatAmount(st.Balance), AsOf: formatOFXTime(asOf)},
+		},
+	}
+	_, err := io.WriteString(w, xml.Header+
+		`<?OFX OFXHEADER="200" VERSION="220" SECURITY="NONE" OLDFILEUID="NONE" NEWFILEUID="NONE"?>`+"\n")
+	if err != nil {
+		return err
+	}
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	err = enc.Encode(doc)
+	if err != nil {
+		return err
+	}
+	_, err = io.WriteString(w, "\n")
+	return err
+}
+
+// ofxType returns the OFX transaction type of a.
+func ofxType(a Activity) string {
+	switch a.Type {
+	case Fee:
+		return "FEE"
+	case Dividend:
+		return "DIV"
+	case Deposit, Withdrawal:
+		return "XFER"
+	}
+	if a.Amount < 0 {
+		return "DEBIT"
+	}
+	return "CREDIT"
+}
+
+func formatOFXTime(t time.Time) string {
+	return t.UTC().Format("20060102150405") + "[0:GMT]"
+}
+
+func formatAmount(f float64) string {
+	return strconv.FormatFloat(f, 'f', 2, 64)
+}
+
+// truncate returns the first n characters of s.
+func truncate(s string, n int) string {
+	r := []rune(s)
+	if len(r) <= n {
+		return s
+	}
+	return string(r[:n])
+}
+
+// OFX documents, restricted to a bank statement.
+type ofx struct {
+	XMLName xml.Name  `xml:"OFX"`
+	SignOn  ofxSignOn `xml:"SIGNONMSGSRSV1>SONRS"`
+	Bank    ofxBank   `xml:"BANKMSGSRSV1>STMTTRNRS"`
+}
+
+type ofxStatus struct {
+	Code     int    `xml:"CODE"`
+	Severity string `xml:"SEVERITY"`
+}
+
+type ofxSignOn struct {
+	Status   ofxStatus `xml:"STATUS"`
+	Server   string    `xml:"DTSERVER"`
+	Language string    `xml:"LANGUAGE"`
+}
+
+type ofxBank struct {
+	TrnUID   string             `xml:"TRNUID"`
+	Status   ofxStatus          `xml:"STATUS"`
+	Currency string             `xml:"STMTRS>CURDEF"`
+	Account  ofxAccount         `xml:"STMTRS>BANKACCTFROM"`
+	List     ofxTransactionList `xml:"STMTRS>BANKTRANLIST"`
+	Balance  ofxBalance         `xml:"STMTRS>LEDGERBAL"`
+}
+
+type ofxAccount struct {
+	BankID string `xml:"BANKID"`
+	ID     string `xml:"ACCTID"`
+	Type   string `xml:"ACCTTYPE"`
+}
+
+type ofxTransactionList struct {
+	Start        string           `xml:"DTSTART"`
+	End          string           `xml:"DTEND"`
+	Transactions []ofxTransaction `xml:"STMTTRN"`
+}
+
+type ofxTransaction struct {
+	Type   string `xml:"TRNTYPE"`
+	Posted string `xml:"DTPOSTED"`
+	Amount string `xml:"TRNAMT"`
+	FITID  string `xml:"FITID"`
+	Name   string `xml:"NAME"`
+	Memo   string `xml:"MEMO"`
+}
+
+type ofxBalance struct {
+	Amount string `xml:"BALAMT"`
+	AsOf   string `xml:"DTASOF"`
+}
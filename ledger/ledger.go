@@ -0,0 +1,358 @@
+// Package ledger merges orders, fills, dividends, transfers, fees and option
+// events into a single chronological ledger of account activity, and exports
+// it for accounting software.
+package ledger
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	rh "github.com/edpin/robinhood"
+)
+
+// Type is the kind of an activity.
+type Type int
+
+// Types of activity.
+const (
+	// Order is an order placed, whether filled or not. Its fills are
+	// separate activities.
+	Order Type = iota + 1
+	Fill
+	Fee
+	Dividend
+	Deposit
+	Withdrawal
+	Expiration
+	Assignment
+	Exercise
+)
+
+// String returns the name of the type, e.g. "fill".
+func (t Type) String() string {
+	switch t {
+	case Order:
+		return "order"
+	case Fill:
+		return "fill"
+	case Fee:
+		return "fee"
+	case Dividend:
+		return "dividend"
+	case Deposit:
+		return "deposit"
+	case Withdrawal:
+		return "withdrawal"
+	case Expiration:
+		return "expiration"
+	case Assignment:
+		return "assignment"
+	case Exercise:
+		return "exercise"
+	default:
+		return "invalid type"
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler. Types are marshalled as
+// their String, including in JSON.
+func (t Type) MarshalText() ([]byte, error) {
+	if t < Order || t > Exercise {
+		return nil, fmt.Errorf("invalid activity type %d", int(t))
+	}
+	return []byte(t.String()), nil
+}
+
+// Activity is something that happened in an account.
+type Activity struct {
+	Time time.Time `json:"time"`
+	Type Type      `json:"type"`
+	// ID identifies the activity among all others, e.g. in OFX exports. It
+	// is the ID of the order, fill, dividend, transfer or event.
+	ID string `json:"id"`
+	// Symbol is of the stock, or the OCC symbol of the option. Empty for
+	// transfers.
+	Symbol      string `json:"symbol,omitempty"`
+	Description string `json:"description"`
+	// Quantity is in shares or contracts, negative if sold or delivered.
+	Quantity float64 `json:"quantity,omitempty"`
+	Price    float64 `json:"price,omitempty"` // Per share.
+	// Amount is the cash received, or paid if negative. It is zero if no
+	// cash moved, e.g. for orders, or dividends and transfers that are
+	// pending, cancelled or failed.
+	Amount float64 `json:"amount"`
+	State  string  `json:"state,omitempty"`
+}
+
+// Sources are the records activities are merged from.
+type Sources struct {
+	// Account is the ID of the account to keep the records of. Records of
+	// every account are kept if empty.
+	Account string
+
+	Orders       []rh.OrderInfo
+	OptionOrders []rh.OptionOrderInfo
+	Dividends    []rh.Dividend
+	Transfers    []rh.Transfer
+	OptionEvents []rh.OptionEvent
+}
+
+// Fetch fetches all records of the user's activity in the client's account,
+// AccountID, or of every account if it is empty, and merges them. See Merge.
+//
+// If the instruments of some orders, option orders or dividends can't be
+// resolved, Fetch returns all activities along with an
+// *rh.UnresolvedInstrumentsError; those activities have no Symbol.
+func Fetch(c *rh.Client) ([]Activity, error) {
+	s := Sources{Account: c.AccountID}
+	var unresolved *rh.UnresolvedInstrumentsError
+	var err error
+	s.Orders, err = c.Orders()
+	if unresolved, err = addUnresolved(unresolved, err); err != nil {
+		return nil, err
+	}
+	s.Dividends, err = c.Dividends()
+	if unresolved, err = addUnresolved(unresolved, err); err != nil {
+		return nil, err
+	}
+	s.OptionOrders, err = c.OptionOrders()
+	if unresolved, err = addUnresolved(unresolved, err); err != nil {
+		return nil, err
+	}
+	s.Transfers, err = c.Transfers()
+	if err != nil {
+		return nil, err
+	}
+	s.OptionEvents, err = c.OptionEvents()
+	if err != nil {
+		return nil, err
+	}
+	if unresolved != nil {
+		return Merge(s), unresolved
+	}
+	return Merge(s), nil
+}
+
+// addUnresolved adds the instruments of err, if it is an
+// *rh.UnresolvedInstrumentsError, to unresolved. It returns err if it is any
+// other error.
+func addUnresolved(unresolved *rh.UnresolvedInstrumentsError, err error) (*rh.UnresolvedInstrumentsError, error) {
+	e, ok := err.(*rh.UnresolvedInstrumentsError)
+	switch {
+	case err == nil:
+		return unresolved, nil
+	case !ok:
+		return unresolved, err
+	case unresolved == nil:
+		return e, nil
+	}
+	unresolved.Instruments = append(unresolved.Instruments, e.Instruments...)
+	unresolved.Errs = append(unresolved.Errs, e.Errs...)
+	return unresolved, nil
+}
+
+// Merge returns the activities of the records in s of s.Account, oldest
+// first. Each order is an activity when placed, each of its fills another,
+// and its fees, if any, another when last filled. Each assignment or exercise
+// of an option is an activity with the cash it moved, and each stock trade it
+// resulted in a fill with no amount.
+func Merge(s Sources) []Activity {
+	var acts []Activity
+	for _, o := range s.Orders {
+		if !s.inAccount(o.Account) {
+			continue
+		}
+		acts = append(acts, Activity{
+			Time:        o.CreatedAt,
+			Type:        Order,
+			ID:          o.ID,
+			Symbol:      o.Symbol,
+			Description: fmt.Sprintf("%s %s order to %s %v %s", o.Duration, o.Type, o.Side, o.Quantity, o.Symbol),
+			Quantity:    signed(o.Side, o.Quantity),
+			Price:       o.Price,
+			State:       o.State,
+		})
+		var last time.Time
+		for _, e := range o.Executions {
+			acts = append(acts, fill(e, o.Symbol, o.Side, 1))
+			if e.Time.After(last) {
+				last = e.Time
+			}
+		}
+		if o.Fees != 0 && !last.IsZero() {
+			acts = append(acts, Activity{
+				Time:        last,
+				Type:        Fee,
+				ID:          o.ID + "-fees",
+				Symbol:      o.Symbol,
+				Description: fmt.Sprintf("Fees of order to %s %s", o.Side, o.Symbol),
+				Amount:      -o.Fees,
+			})
+		}
+	}
+	for _, o := range s.OptionOrders {
+		if !s.inAccount(o.Account) {
+			continue
+		}
+		side := "credit"
+		if o.Debit {
+			side = "debit"
+		}
+		acts = append(acts, Activity{
+			Time:        o.CreatedAt,
+			Type:        Order,
+			ID:          o.ID,
+			Symbol:      o.Symbol,
+			Description: fmt.Sprintf("%s %s option order of %d legs for a %s of %.2f", o.Duration, o.Type, len(o.Legs), side, o.Price),
+			Quantity:    o.Quantity,
+			Price:       o.Price,
+			State:       o.State,
+		})
+		var last time.Time
+		for _, l := range o.Legs {
+			for _, e := range l.Executions {
+				symbol := ""
+				if l.Chain.Type != 0 {
+					symbol = l.Chain.OCC()
+				}
+				acts = append(acts, fill(e, symbol, l.Side, 100))
+				if e.Time.After(last) {
+					last = e.Time
+				}
+			}
+		}
+		if o.Fees != 0 && !last.IsZero() {
+			acts = append(acts, Activity{
+				Time:        last,
+				Type:        Fee,
+				ID:          o.ID + "-fees",
+				Symbol:      o.Symbol,
+				Description: fmt.Sprintf("Fees of %s option order", o.Symbol),
+				Amount:      -o.Fees,
+			})
+		}
+	}
+	for _, d := range s.Dividends {
+		if !s.inAccount(d.Account) {
+			continue
+		}
+		a := Activity{
+			Time:        d.PayableDate,
+			Type:        Dividend,
+			ID:          d.ID,
+			Symbol:      d.Symbol,
+			Description: fmt.Sprintf("Dividend of %v per share on %v %s", d.Rate, d.Position, d.Symbol),
+			Quantity:    d.Position,
+			Price:       d.Rate,
+			State:       d.State,
+		}
+		if !d.PaidAt.IsZero() {
+			a.Time = d.PaidAt
+		}
+		if d.State == "paid" || d.State == "reinvested" {
+			a.Amount = d.Amount - d.Withholding
+		}
+		acts = append(acts, a)
+	}
+	for _, t := range s.Transfers {
+		if !s.inAccount(t.Account) {
+			continue
+		}
+		a := Activity{
+			Time:        t.CreatedAt,
+			Type:        Deposit,
+			ID:          t.ID,
+			Description: fmt.Sprintf("ACH deposit of %.2f", t.Amount),
+			State:       t.State,
+		}
+		amount := t.Amount - t.Fees
+		if t.Direction == rh.Withdrawal {
+			a.Type = Withdrawal
+			a.Description = fmt.Sprintf("ACH withdrawal of %.2f", t.Amount)
+			amount = -t.Amount - t.Fees
+		}
+		if t.State == "completed" {
+			a.Amount = amount
+		}
+		acts = append(acts, a)
+	}
+	for _, e := range s.OptionEvents {
+		if !s.inAccount(e.Account) {
+			continue
+		}
+		a := Activity{
+			Time:        e.Date,
+			Type:        Expiration,
+			ID:          e.ID,
+			Symbol:      e.Chain.OCC(),
+			Description: fmt.Sprintf("Expiration of %v %s", e.Quantity, e.Chain.OCC()),
+			Quantity:    -e.Quantity,
+			Amount:      e.CashAmount,
+			State:       e.State,
+		}
+		switch e.Type {
+		case "assignment":
+			a.Type = Assignment
+			a.Description = fmt.Sprintf("Assignment of %v %s", e.Quantity, e.Chain.OCC())
+		case "exercise":
+			a.Type = Exercise
+			a.Description = fmt.Sprintf("Exercise of %v %s", e.Quantity, e.Chain.OCC())
+		}
+		for _, c := range e.Components {
+			a.Description += fmt.Sprintf(": %s %v %s at %.2f", c.Side, c.Quantity, c.Symbol, c.Price)
+		}
+		acts = append(acts, a)
+		// The stock delivered, with no amount: the cash is the event's.
+		for k, c := range e.Components {
+			acts = append(acts, Activity{
+				Time:        e.Date,
+				Type:        Fill,
+				ID:          fmt.Sprintf("%s-%d", e.ID, k+1),
+				Symbol:      c.Symbol,
+				Description: fmt.Sprintf("%s %v %s at %.2f on %s of %s", c.Side, c.Quantity, c.Symbol, c.Price, e.Type, e.Chain.OCC()),
+				Quantity:    signed(c.Side, c.Quantity),
+				Price:       c.Price,
+				State:       e.State,
+			})
+		}
+	}
+	sort.SliceStable(acts, func(i, j int) bool {
+		if !acts[i].Time.Equal(acts[j].Time) {
+			return acts[i].Time.Before(acts[j].Time)
+		}
+		return acts[i].Type < acts[j].Type
+	})
+	return acts
+}
+
+// inAccount returns whether u, the URL of an account, is s.Account. Every
+// account is if s.Account is empty.
+func (s Sources) inAccount(u string) bool {
+	return s.Account == "" || rh.AccountID(u) == s.Account
+}
+
+// fill returns the activity of an execution of an order to trade symbol,
+// with multiplier shares per unit.
+func fill(e rh.Execution, symbol string, side rh.Side, multiplier float64) Activity {
+	q := signed(side, e.Quantity)
+	return Activity{
+		Time:        e.Time,
+		Type:        Fill,
+		ID:          e.ID,
+		Symbol:      symbol,
+		Description: fmt.Sprintf("%s %v %s at %.2f", side, e.Quantity, symbol, e.Price),
+		Quantity:    q,
+		Price:       e.Price,
+		Amount:      -q * e.Price * multiplier,
+	}
+}
+
+// signed returns quantity, negated if side sells.
+func signed(side rh.Side, quantity float64) float64 {
+	switch side {
+	case rh.Sell, rh.SellToOpen, rh.SellToClose:
+		return -quantity
+	}
+	return quantity
+}
@@ -0,0 +1,188 @@
+package ledger
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	rh "github.com/edpin/robinhood"
+)
+
+func at(m time.Month, d, h int) time.Time {
+	return time.Date(2018, m, d, h, 0, 0, 0, time.UTC)
+}
+
+var put = rh.Chain{Symbol: "SPY", Strike: 270, Expiration: time.Date(2018, 7, 20, 0, 0, 0, 0, time.UTC), Type: rh.Put}
+
+var sources = Sources{
+	Orders: []rh.OrderInfo{{
+		ID:        "o1",
+		Symbol:    "AAPL",
+		Side:      rh.Sell,
+		Type:      rh.Limit,
+		State:     "filled",
+		Quantity:  10,
+		Price:     190,
+		Fees:      0.02,
+		CreatedAt: at(6, 20, 14),
+		Executions: []rh.Execution{
+			{ID: "e1", Time: at(6, 20, 15), Quantity: 4, Price: 190},
+			{ID: "e2", Time: at(6, 20, 16), Quantity: 6, Price: 191},
+		},
+	}},
+	OptionOrders: []rh.OptionOrderInfo{{
+		ID:        "oo1",
+		Symbol:    "SPY",
+		State:     "filled",
+		Quantity:  1,
+		Price:     1.2,
+		Fees:      0.04,
+		CreatedAt: at(6, 18, 14),
+		Legs: []rh.OptionOrderLeg{{
+			Chain:      put,
+			Side:       rh.SellToOpen,
+			Executions: []rh.Execution{{ID: "x1", Time: at(6, 18, 15), Quantity: 1, Price: 1.2}},
+		}},
+	}},
+	Dividends: []rh.Dividend{
+		{ID: "d1", Symbol: "AAPL", Amount: 7.3, Rate: 0.73, Position: 10, PayableDate: at(5, 17, 0), PaidAt: at(5, 18, 2), State: "paid"},
+		{ID: "d2", Symbol: "AAPL", Amount: 7.3, Rate: 0.73, Position: 10, PayableDate: at(8, 16, 0), State: "pending"},
+	},
+	Transfers: []rh.Transfer{
+		{ID: "t1", Direction: rh.Deposit, Amount: 500, State: "completed", CreatedAt: at(5, 1, 12)},
+		{ID: "t2", Direction: rh.Withdrawal, Amount: 100, State: "cancelled", CreatedAt: at(7, 1, 12)},
+	},
+	OptionEvents: []rh.OptionEvent{{
+		ID:         "ev1",
+		Type:       "assignment",
+		Chain:      put,
+		Quantity:   1,
+		CashAmount: -27000,
+		Date:       time.Date(2018, 7, 20, 0, 0, 0, 0, time.UTC),
+		State:      "confirmed",
+		Components: []rh.EventComponent{{Symbol: "SPY", Side: rh.Buy, Quantity: 100, Price: 270}},
+	}},
+}
+
+func TestMerge(t *testing.T) {
+	acts := Merge(sources)
+	want := []struct {
+		id     string
+		typ    Type
+		amount float64
+	}{
+		{"t1", Deposit, 500},
+		{"d1", Dividend, 7.3},
+		{"oo1", Order, 0},
+		{"x1", Fill, 120},
+		{"oo1-fees", Fee, -0.04},
+		{"o1", Order, 0},
+		{"e1", Fill, 760},
+		{"e2", Fill, 1146},
+		{"o1-fees", Fee, -0.02},
+		{"t2", Withdrawal, 0},
+		{"ev1-1", Fill, 0},
+		{"ev1", Assignment, -27000},
+		{"d2", Dividend, 0},
+	}
+	if len(acts) != len(want) {
+		t.Fatalf("got %d activities, want %d: %+v", len(acts), len(want), acts)
+	}
+	for i, w := range want {
+		a := acts[i]
+		if a.ID != w.id || a.Type != w.typ || math.Abs(a.Amount-w.amount) > 1e-9 {
+			t.Errorf("acts[%d] = %+v, want %s %s %v", i, a, w.id, w.typ, w.amount)
+		}
+	}
+	if x1 := acts[3]; x1.Symbol != put.OCC() || x1.Quantity != -1 {
+		t.Errorf("option fill = %+v", x1)
+	}
+	if stock := acts[10]; stock.Symbol != "SPY" || stock.Quantity != 100 || stock.Price != 270 {
+		t.Errorf("assigned stock = %+v", stock)
+	}
+	if ev := acts[11]; !strings.Contains(ev.Description, "buy 100 SPY at 270.00") {
+		t.Errorf("assignment description = %q", ev.Description)
+	}
+}
+
+func TestMergeAccount(t *testing.T) {
+	const mine, other = "https://api.robinhood.com/accounts/mine/", "https://api.robinhood.com/accounts/other/"
+	s := Sources{
+		Account:   "mine",
+		Dividends: []rh.Dividend{{ID: "d1", Account: mine}, {ID: "d2", Account: other}},
+		Transfers: []rh.Transfer{{ID: "t1", Account: other}, {ID: "t2", Account: mine}},
+	}
+	acts := Merge(s)
+	if len(acts) != 2 || acts[0].ID != "d1" || acts[1].ID != "t2" {
+		t.Errorf("activities = %+v, want d1 and t2", acts)
+	}
+	s.Account = ""
+	if acts := Merge(s); len(acts) != 4 {
+		t.Errorf("got %d activities of every account, want 4", len(acts))
+	}
+}
+
+func TestExports(t *testing.T) {
+	acts := Merge(Sources{Transfers: sources.Transfers, Dividends: sources.Dividends[:1]})
+
+	var buf bytes.Buffer
+	if err := WriteCSV(&buf, acts); err != nil {
+		t.Fatal(err)
+	}
+	wantCSV := "time,type,id,symbol,description,quantity,price,amount,state\n" +
+		"2018-05-01T12:00:00Z,deposit,t1,,ACH deposit of 500.00,0,0,500.00,completed\n" +
+		"2018-05-18T02:00:00Z,dividend,d1,AAPL,Dividend of 0.73 per share on 10 AAPL,10,0.73,7.30,paid\n" +
+		"2018-07-01T12:00:00Z,withdrawal,t2,,ACH withdrawal of 100.00,0,0,0.00,cancelled\n"
+	if got := buf.String(); got != wantCSV {
+		t.Errorf("CSV:\n%s\nwant:\n%s", got, wantCSV)
+	}
+
+	buf.Reset()
+	if err := WriteJSON(&buf, acts); err != nil {
+		t.Fatal(err)
+	}
+	var decoded []map[string]interface{}
+	if err := json.Unmarshal(buf.Bytes(), &decoded); err != nil {
+		t.Fatal(err)
+	}
+	if len(decoded) != 3 || decoded[1]["type"] != "dividend" || decoded[1]["amount"] != 7.3 {
+		t.Errorf("JSON = %s", buf.String())
+	}
+
+	buf.Reset()
+	if err := WriteQIF(&buf, acts); err != nil {
+		t.Fatal(err)
+	}
+	wantQIF := "!Type:Bank\n" +
+		"D05/01/2018\nT500.00\nPACH deposit of 500.00\nMdeposit t1\n^\n" +
+		"D05/18/2018\nT7.30\nPDividend of 0.73 per share on 10 AAPL\nMdividend d1\n^\n"
+	if got := buf.String(); got != wantQIF {
+		t.Errorf("QIF:\n%s\nwant:\n%s", got, wantQIF)
+	}
+
+	buf.Reset()
+	if err := WriteOFX(&buf, Statement{Account: "5RY82436", Balance: 507.3}, acts); err != nil {
+		t.Fatal(err)
+	}
+	ofx := buf.String()
+	for _, want := range []string{
+		`<?OFX OFXHEADER="200" VERSION="220"`,
+		"<ACCTID>5RY82436</ACCTID>",
+		"<DTSTART>20180501120000[0:GMT]</DTSTART>",
+		"<DTEND>20180701120000[0:GMT]</DTEND>",
+		"<TRNTYPE>DIV</TRNTYPE>",
+		"<TRNAMT>7.30</TRNAMT>",
+		"<FITID>d1</FITID>",
+		"<BALAMT>507.30</BALAMT>",
+	} {
+		if !strings.Contains(ofx, want) {
+			t.Errorf("OFX is missing %s:\n%s", want, ofx)
+		}
+	}
+	if strings.Contains(ofx, "<FITID>t2</FITID>") {
+		t.Errorf("OFX includes the cancelled withdrawal:\n%s", ofx)
+	}
+}
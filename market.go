@@ -0,0 +1,159 @@
+package robinhood
+
+// This file deals with market hours and the trading calendar.
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// DefaultMarket is the MIC of the market whose hours MarketHours, IsOpen,
+// NextOpen and NextClose report: the New York Stock Exchange.
+const DefaultMarket = "XNYS"
+
+// maxClosedDays bounds how far NextOpen and NextClose look ahead for a trading
+// day. No US market has ever been closed for this long outside of wartime.
+const maxClosedDays = 14
+
+// marketLocation is the time zone US markets operate in. Dates passed to
+// MarketHours are interpreted in it.
+var marketLocation = loadMarketLocation()
+
+func loadMarketLocation() *time.Location {
+	loc, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		// No time zone database available. Standard time is wrong during
+		// daylight saving time, but only by an hour and only for dates near
+		// midnight.
+		return time.FixedZone("EST", -5*60*60)
+	}
+	return loc
+}
+
+// MarketHours describes when a market trades on a given day. All times are in
+// the market's time zone and are zero on days the market is closed.
+type MarketHours struct {
+	Market string    // MIC of the market, e.g. "XNYS".
+	Date   time.Time // Midnight at the start of the day.
+	IsOpen bool      // Whether the market trades at all on Date.
+
+	OpensAt          time.Time
+	ClosesAt         time.Time
+	ExtendedOpensAt  time.Time
+	ExtendedClosesAt time.Time
+}
+
+// IsOpenAt returns whether t falls within the regular trading session.
+func (h MarketHours) IsOpenAt(t time.Time) bool {
+	return h.IsOpen && !t.Before(h.OpensAt) && t.Before(h.ClosesAt)
+}
+
+// IsExtendedOpenAt returns whether t falls within the extended trading
+// session, which includes the regular session.
+func (h MarketHours) IsExtendedOpenAt(t time.Time) bool {
+	return h.IsOpen && !t.Before(h.ExtendedOpensAt) && t.Before(h.ExtendedClosesAt)
+}
+
+// MarketHours returns the hours of DefaultMarket on the given date. Only the
+// date part matters, as seen in the market's time zone.
+func (c *Client) MarketHours(date time.Time) (MarketHours, error) {
+	return c.MarketHoursFor(DefaultMarket, date)
+}
+
+// MarketHoursFor is like MarketHours but for the market with the given MIC,
+// such as InstrumentInfo.Market.
+func (c *Client) MarketHoursFor(market string, date time.Time) (MarketHours, error) {
+	c.init()
+	day := date.In(marketLocation).Format(dateFormat)
+	key := market + "/" + day
+	if v, ok := c.hours.get(key); ok {
+		return v.(MarketHours), nil
+	}
+	resp, err := c.get(marketsURI + market + "/hours/" + day + "/")
+	if err != nil {
+		return MarketHours{}, err
+	}
+	var mh marketHours
+	err = json.Unmarshal(resp, &mh)
+	if err != nil {
+		return MarketHours{}, err
+	}
+	d, err := time.ParseInLocation(dateFormat, mh.Date, marketLocation)
+	if err != nil {
+		return MarketHours{}, err
+	}
+	h := MarketHours{
+		Market: market,
+		Date:   d,
+		IsOpen: mh.IsOpen,
+	}
+	if h.IsOpen {
+		h.OpensAt, err = parseMarketTime(mh.OpensAt, nil)
+		h.ClosesAt, err = parseMarketTime(mh.ClosesAt, err)
+		h.ExtendedOpensAt, err = parseMarketTime(mh.ExtendedOpensAt, err)
+		h.ExtendedClosesAt, err = parseMarketTime(mh.ExtendedClosesAt, err)
+		if err != nil {
+			return MarketHours{}, fmt.Errorf("error parsing hours of %s on %s: %v", market, day, err)
+		}
+	}
+	c.hours.set(key, h)
+	return h, nil
+}
+
+// IsOpen returns whether DefaultMarket is in its regular trading session at
+// time now.
+func (c *Client) IsOpen(now time.Time) (bool, error) {
+	h, err := c.MarketHours(now)
+	if err != nil {
+		return false, err
+	}
+	return h.IsOpenAt(now), nil
+}
+
+// NextOpen returns the start of the next regular trading session of
+// DefaultMarket after now.
+func (c *Client) NextOpen(now time.Time) (time.Time, error) {
+	return c.nextSession(now, func(h MarketHours) time.Time { return h.OpensAt })
+}
+
+// NextClose returns the end of the current regular trading session of
+// DefaultMarket, or of the next one if the market is closed at time now.
+func (c *Client) NextClose(now time.Time) (time.Time, error) {
+	return c.nextSession(now, func(h MarketHours) time.Time { return h.ClosesAt })
+}
+
+// nextSession returns the earliest time after now that is returned by at() for
+// a trading day, looking at most maxClosedDays ahead.
+func (c *Client) nextSession(now time.Time, at func(MarketHours) time.Time) (time.Time, error) {
+	day := now.In(marketLocation)
+	for i := 0; i <= maxClosedDays; i++ {
+		h, err := c.MarketHours(day.AddDate(0, 0, i))
+		if err != nil {
+			return time.Time{}, err
+		}
+		if h.IsOpen && at(h).After(now) {
+			return at(h), nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("market %s closed for more than %d days after %v", DefaultMarket, maxClosedDays, now)
+}
+
+type marketHours struct {
+	Date             string `json:"date"`
+	IsOpen           bool   `json:"is_open"`
+	OpensAt          string `json:"opens_at"`
+	ClosesAt         string `json:"closes_at"`
+	ExtendedOpensAt  string `json:"extended_opens_at"`
+	ExtendedClosesAt string `json:"extended_closes_at"`
+}
+
+// parseMarketTime parses an RFC 3339 timestamp into the market's time zone. Like
+// parseFloat64, it returns prevErr if not nil.
+func parseMarketTime(str string, prevErr error) (time.Time, error) {
+	t, err := time.Parse(time.RFC3339, str)
+	if prevErr != nil {
+		return t, prevErr
+	}
+	return t.In(marketLocation), err
+}
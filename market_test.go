@@ -0,0 +1,92 @@
+package robinhood
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	httpmock "gopkg.in/jarcoal/httpmock.v1"
+)
+
+var marketHoursReplies = map[string]string{
+	apiURL + marketsURI + "XNYS/hours/2018-07-03/": `{"closes_at":"2018-07-03T17:00:00Z","extended_opens_at":"2018-07-03T13:00:00Z","next_open_hours":"https://api.robinhood.com/markets/XNYS/hours/2018-07-05/","previous_open_hours":"https://api.robinhood.com/markets/XNYS/hours/2018-07-02/","is_open":true,"extended_closes_at":"2018-07-03T19:00:00Z","date":"2018-07-03","opens_at":"2018-07-03T13:30:00Z"}`,
+	apiURL + marketsURI + "XNYS/hours/2018-07-04/": `{"closes_at":null,"extended_opens_at":null,"next_open_hours":"https://api.robinhood.com/markets/XNYS/hours/2018-07-05/","previous_open_hours":"https://api.robinhood.com/markets/XNYS/hours/2018-07-03/","is_open":false,"extended_closes_at":null,"date":"2018-07-04","opens_at":null}`,
+	apiURL + marketsURI + "XNYS/hours/2018-07-05/": `{"closes_at":"2018-07-05T20:00:00Z","extended_opens_at":"2018-07-05T13:00:00Z","next_open_hours":"https://api.robinhood.com/markets/XNYS/hours/2018-07-06/","previous_open_hours":"https://api.robinhood.com/markets/XNYS/hours/2018-07-03/","is_open":true,"extended_closes_at":"2018-07-05T22:00:00Z","date":"2018-07-05","opens_at":"2018-07-05T13:30:00Z"}`,
+}
+
+func TestMarketHours(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	calls := 0
+	for url, reply := range marketHoursReplies {
+		reply := reply
+		httpmock.RegisterResponder("GET", url, func(req *http.Request) (*http.Response, error) {
+			calls++
+			return httpmock.NewStringResponse(200, reply), nil
+		})
+	}
+
+	c := Client{Token: "token"}
+	utc := func(s string) time.Time {
+		tm, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return tm
+	}
+
+	// 2018-07-04T02:00Z is still July 3rd in New York.
+	h, err := c.MarketHours(utc("2018-07-04T02:00:00Z"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !h.IsOpen || h.Date.Format(dateFormat) != "2018-07-03" || !h.ClosesAt.Equal(utc("2018-07-03T17:00:00Z")) || !h.ExtendedOpensAt.Equal(utc("2018-07-03T13:00:00Z")) {
+		t.Errorf("unexpected hours: %+v", h)
+	}
+	if h.OpensAt.Hour() != 9 || h.OpensAt.Minute() != 30 {
+		t.Errorf("OpensAt = %v, want 9:30 local time", h.OpensAt)
+	}
+	if !h.IsExtendedOpenAt(utc("2018-07-03T18:00:00Z")) || h.IsOpenAt(utc("2018-07-03T18:00:00Z")) {
+		t.Errorf("wrong session at 18:00Z: %+v", h)
+	}
+
+	tests := []struct {
+		now       string
+		open      bool
+		nextOpen  string
+		nextClose string
+	}{
+		{"2018-07-03T12:00:00Z", false, "2018-07-03T13:30:00Z", "2018-07-03T17:00:00Z"},
+		{"2018-07-03T14:00:00Z", true, "2018-07-05T13:30:00Z", "2018-07-03T17:00:00Z"},
+		{"2018-07-03T17:00:00Z", false, "2018-07-05T13:30:00Z", "2018-07-05T20:00:00Z"},
+		{"2018-07-04T15:00:00Z", false, "2018-07-05T13:30:00Z", "2018-07-05T20:00:00Z"},
+	}
+	for _, test := range tests {
+		now := utc(test.now)
+		open, err := c.IsOpen(now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if open != test.open {
+			t.Errorf("IsOpen(%s) = %v, want %v", test.now, open, test.open)
+		}
+		next, err := c.NextOpen(now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !next.Equal(utc(test.nextOpen)) {
+			t.Errorf("NextOpen(%s) = %v, want %s", test.now, next, test.nextOpen)
+		}
+		next, err = c.NextClose(now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !next.Equal(utc(test.nextClose)) {
+			t.Errorf("NextClose(%s) = %v, want %s", test.now, next, test.nextClose)
+		}
+	}
+	if calls != 3 {
+		t.Errorf("calls = %d, want 3", calls)
+	}
+}
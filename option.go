@@ -2,7 +2,10 @@ package robinhood
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 	"time"
 )
 
@@ -13,18 +16,51 @@ type Option struct {
 	Symbol      string // the underlying symbol.
 	Strike      float64
 	Expiration  time.Time
-	Type        string // "put" or "call". TODO: use an enum?
+	Type        OptionType
 	Bid         float64
 	Ask         float64
 	Last        float64
 	MarketPrice float64 // Close to midpoint, but not quite.
 	Volume      int64
 	IV          float64
-	// TODO: add other fields such as AskSize, BidSize, greeks, etc.
+
+	BidSize       int64
+	AskSize       int64
+	LastSize      int64
+	Mark          float64 // Midpoint of bid and ask.
+	High          float64 // Zero if the option hasn't traded today.
+	Low           float64 // Zero if the option hasn't traded today.
+	PreviousClose float64
+	// PreviousCloseDate is the date PreviousClose is from. Zero if unknown.
+	PreviousCloseDate time.Time
+	OpenInterest      int64
+
+	// BreakEven is the underlying price at which buying the option at its
+	// market price breaks even at expiration.
+	BreakEven float64
+	// ChanceOfProfitLong and ChanceOfProfitShort are the estimated
+	// probabilities, between 0 and 1, of profiting from buying or selling the
+	// option at its market price and holding it until expiration.
+	ChanceOfProfitLong  float64
+	ChanceOfProfitShort float64
+
+	Greeks Greeks
 
 	instrument Instrument // URL of instrument. Used for placing orders.
 }
 
+// Greeks are the sensitivities of an option's price. Theta is per calendar
+// day, and Vega and Rho are per percentage point change in volatility and
+// interest rate, respectively. Greeks are zero when the server can't compute
+// them, e.g. for options without a market.
+type Greeks struct {
+	Delta float64
+	Gamma float64
+	Theta float64
+	Vega  float64
+	Rho   float64
+}
+
 // Option returns a quote for an option chain.
 func (c *Client) Option(chain Chain) (Option, error) {
 	var o0 Option
@@ -42,34 +78,173 @@ func (c *Client) Option(chain Chain) (Option, error) {
 	if err != nil {
 		return o0, err
 	}
+	return newOption(chain, o)
+}
+
+// maxOptionsPerRequest is the maximum number of option instruments sent in a
+// single ?instruments= request. Each instrument is a full URL, so this is lower
+// than maxSymbolsPerRequest.
+const maxOptionsPerRequest = 40
+
+// Options returns quotes for many option chains at once. The result is aligned
+// to chains: the i-th Option is the quote for chains[i]. Long lists of chains
+// are split into several concurrent requests.
+//
+// If the server has no market data for some chains, their Options are left
+// zero and a *MissingMarketDataError listing them is returned along with the
+// quotes for all other chains.
+func (c *Client) Options(chains []Chain) ([]Option, error) {
+	opts := make([]Option, len(chains))
+	found := make([]bool, len(chains))
+	err := forEachBatch(len(chains), maxOptionsPerRequest, func(start, end int) error {
+		var instruments []string
+		for _, ch := range chains[start:end] {
+			if ch.id == "" {
+				return fmt.Errorf("chain %v has no instrument; use one returned by Chains", ch)
+			}
+			instruments = append(instruments, apiURL+optionsURI+ch.id+"/")
+		}
+		parms := url.Values{}
+		parms.Set("instruments", strings.Join(instruments, ","))
+		req, err := http.NewRequest("GET", apiURL+marketOptionsURI+"?"+parms.Encode(), nil)
+		if err != nil {
+			return err
+		}
+		resp, err := c.doReqWithBearerToken(req)
+		if err != nil {
+			return err
+		}
+		var results map[string][]*option
+		err = json.Unmarshal(resp, &results)
+		if err != nil {
+			return err
+		}
+		byID := make(map[string]option)
+		for _, o := range results["results"] {
+			if o != nil {
+				byID[Instrument(o.Instrument).GetID()] = *o
+			}
+		}
+		for i := start; i < end; i++ {
+			o, ok := byID[chains[i].id]
+			if !ok {
+				continue
+			}
+			opts[i], err = newOption(chains[i], o)
+			if err != nil {
+				return err
+			}
+			found[i] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	var missing []Chain
+	for i, ok := range found {
+		if !ok {
+			missing = append(missing, chains[i])
+		}
+	}
+	if len(missing) > 0 {
+		return opts, &MissingMarketDataError{Chains: missing}
+	}
+	return opts, nil
+}
+
+// MissingMarketDataError is returned by Options, along with the quotes for all
+// other chains, when the server has no market data for some chains.
+type MissingMarketDataError struct {
+	Chains []Chain
+}
+
+// Error implements error.
+func (e *MissingMarketDataError) Error() string {
+	return fmt.Sprintf("no market data for %d option chains", len(e.Chains))
+}
+
+// newOption converts the market data o for chain to the external format.
+func newOption(chain Chain, o option) (Option, error) {
 	bid, err := parseFloat64(o.Bid, nil)
 	ask, err := parseFloat64(o.Ask, err)
 	last, err := parseOptionalFloat64(o.Last, err)
 	marketPrice, err := parseFloat64(o.MarketPrice, err)
 	iv, err := parseOptionalFloat64(o.IV, err)
+	mark, err := parseOptionalFloat64(o.Mark, err)
+	high, err := parseOptionalFloat64(o.High, err)
+	low, err := parseOptionalFloat64(o.Low, err)
+	prevClose, err := parseOptionalFloat64(o.PreviousClose, err)
+	breakEven, err := parseOptionalFloat64(o.BreakEven, err)
+	copLong, err := parseOptionalFloat64(o.ChanceOfProfitLong, err)
+	copShort, err := parseOptionalFloat64(o.ChanceOfProfitShort, err)
+	delta, err := parseOptionalFloat64(o.Delta, err)
+	gamma, err := parseOptionalFloat64(o.Gamma, err)
+	theta, err := parseOptionalFloat64(o.Theta, err)
+	vega, err := parseOptionalFloat64(o.Vega, err)
+	rho, err := parseOptionalFloat64(o.Rho, err)
+	var prevCloseDate time.Time
+	if o.PreviousCloseDate != "" && err == nil {
+		prevCloseDate, err = time.Parse(dateFormat, o.PreviousCloseDate)
+	}
 	option := Option{
-		Symbol:      chain.Symbol,
-		Strike:      chain.Strike,
-		Expiration:  chain.Expiration,
-		Type:        chain.Type,
-		Bid:         bid,
-		Ask:         ask,
-		Last:        last,
-		Volume:      o.Volume,
-		MarketPrice: marketPrice,
-		IV:          iv,
-		instrument:  Instrument(o.Instrument),
+		Symbol:              chain.Symbol,
+		Strike:              chain.Strike,
+		Expiration:          chain.Expiration,
+		Type:                chain.Type,
+		Bid:                 bid,
+		Ask:                 ask,
+		Last:                last,
+		Volume:              o.Volume,
+		MarketPrice:         marketPrice,
+		IV:                  iv,
+		BidSize:             o.BidSize,
+		AskSize:             o.AskSize,
+		LastSize:            o.LastSize,
+		Mark:                mark,
+		High:                high,
+		Low:                 low,
+		PreviousClose:       prevClose,
+		PreviousCloseDate:   prevCloseDate,
+		OpenInterest:        o.OpenInterest,
+		BreakEven:           breakEven,
+		ChanceOfProfitLong:  copLong,
+		ChanceOfProfitShort: copShort,
+		Greeks: Greeks{
+			Delta: delta,
+			Gamma: gamma,
+			Theta: theta,
+			Vega:  vega,
+			Rho:   rho,
+		},
+		instrument: Instrument(o.Instrument),
 	}
 	return option, err
 }
 
 type option struct {
-	Ask         string `json:"ask_price"`
-	Bid         string `json:"bid_price"`
-	Last        string `json:"last_trade_price"`
-	MarketPrice string `json:"adjusted_mark_price"`
-	Volume      int64  `json:"volume"`
-	IV          string `json:"implied_volatility"`
-	Instrument  string `json:"instrument"`
-	// Other fields available...
+	Ask                 string `json:"ask_price"`
+	Bid                 string `json:"bid_price"`
+	Last                string `json:"last_trade_price"`
+	MarketPrice         string `json:"adjusted_mark_price"`
+	Volume              int64  `json:"volume"`
+	IV                  string `json:"implied_volatility"`
+	Instrument          string `json:"instrument"`
+	AskSize             int64  `json:"ask_size"`
+	BidSize             int64  `json:"bid_size"`
+	LastSize            int64  `json:"last_trade_size"`
+	Mark                string `json:"mark_price"`
+	High                string `json:"high_price"`
+	Low                 string `json:"low_price"`
+	PreviousClose       string `json:"previous_close_price"`
+	PreviousCloseDate   string `json:"previous_close_date"`
+	OpenInterest        int64  `json:"open_interest"`
+	BreakEven           string `json:"break_even_price"`
+	ChanceOfProfitLong  string `json:"chance_of_profit_long"`
+	ChanceOfProfitShort string `json:"chance_of_profit_short"`
+	Delta               string `json:"delta"`
+	Gamma               string `json:"gamma"`
+	Theta               string `json:"theta"`
+	Vega                string `json:"vega"`
+	Rho                 string `json:"rho"`
 }
@@ -1,7 +1,11 @@
 package robinhood
 
 import (
+	"fmt"
+	"net/http"
+	"strconv"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -9,10 +13,10 @@ import (
 )
 
 var options = map[string]string{
-	apiURL + quotesURI + "SPY/":                                                                                                          `{"ask_price":"274.5500","bid_price":"274.5000","symbol":"SPY","instrument":"https://api.robinhood.com/instruments/8f92e76f-1e0e-4478-8580-16a6ffcfaef5/"}`,
+	apiURL + instrumentsURI + "?symbol=SPY":                                                                                              `{"previous":null,"results":[{"margin_initial_ratio":"0.5000","rhs_tradability":"tradable","id":"8f92e76f-1e0e-4478-8580-16a6ffcfaef5","market":"https://api.robinhood.com/markets/ARCX/","simple_name":null,"min_tick_size":null,"maintenance_ratio":"0.2500","tradability":"tradable","state":"active","type":"etp","tradeable":true,"fundamentals":"https://api.robinhood.com/fundamentals/SPY/","quote":"https://api.robinhood.com/quotes/SPY/","symbol":"SPY","day_trade_ratio":"0.2500","name":"SPDR S&P 500 ETF Trust","tradable_chain_id":"c277b118-58d9-4060-8dc5-a3b5898955cb","splits":"https://api.robinhood.com/instruments/8f92e76f-1e0e-4478-8580-16a6ffcfaef5/splits/","url":"https://api.robinhood.com/instruments/8f92e76f-1e0e-4478-8580-16a6ffcfaef5/","country":"US","bloomberg_unique":"EQ0000000000021232","list_date":"1993-01-29"}],"next":null}`,
 	apiURL + chainsURI + "?equity_instrument_ids=8f92e76f-1e0e-4478-8580-16a6ffcfaef5":                                                   `{"previous":null,"results":[{"can_open_position":true,"symbol":"SPY","trade_value_multiplier":"100.0000","underlying_instruments":[{"instrument":"https:\/\/api.robinhood.com\/instruments\/8f92e76f-1e0e-4478-8580-16a6ffcfaef5\/","id":"6c3bf803-ec29-41c1-b721-3471351fc61d","quantity":100}],"expiration_dates":["2018-06-27","2018-06-29","2018-07-02","2018-07-03","2018-07-06","2018-07-09","2018-07-11","2018-07-13","2018-07-16","2018-07-18","2018-07-20","2018-07-23","2018-07-25","2018-07-27","2018-07-30","2018-08-03","2018-08-17","2018-09-21","2018-09-28","2018-10-19","2018-12-21","2018-12-31","2019-01-18","2019-03-15","2019-03-29","2019-06-21","2019-09-20","2019-12-20","2020-01-17","2020-03-20","2020-06-19","2020-12-18"],"cash_component":null,"min_ticks":{"cutoff_price":"0.00","below_tick":"0.01","above_tick":"0.01"},"id":"c277b118-58d9-4060-8dc5-a3b5898955cb"},{"can_open_position":false,"symbol":"2SPY","trade_value_multiplier":"100.0000","underlying_instruments":[{"instrument":"https:\/\/api.robinhood.com\/instruments\/8f92e76f-1e0e-4478-8580-16a6ffcfaef5\/","id":"ca3c00f6-2477-485e-940b-90f173ada716","quantity":100}],"expiration_dates":[],"cash_component":null,"min_ticks":{"cutoff_price":"3.00","below_tick":"0.01","above_tick":"0.05"},"id":"74ecfc8e-3fee-4e70-85b6-d9fe755c96cc"},{"can_open_position":false,"symbol":"1SPY","trade_value_multiplier":"100.0000","underlying_instruments":[{"instrument":"https:\/\/api.robinhood.com\/instruments\/8f92e76f-1e0e-4478-8580-16a6ffcfaef5\/","id":"5b5a0dde-1f02-43a5-ac9a-5eb104dd380d","quantity":100}],"expiration_dates":[],"cash_component":null,"min_ticks":{"cutoff_price":"3.00","below_tick":"0.01","above_tick":"0.05"},"id":"de653940-25c0-4e35-986a-989737498881"}],"next":null}`,
 	apiURL + optionsURI + "?chain_id=c277b118-58d9-4060-8dc5-a3b5898955cb&expiration_dates=2018-06-29&state=active&tradability=tradable": `{"previous":null,"results":[{"issue_date":"2005-01-06","tradability":"tradable","strike_price":"296.0000","url":"https:\/\/api.robinhood.com\/options\/instruments\/8ada9799-6c34-4647-b3ee-b6c157745740\/","expiration_date":"2018-06-29","created_at":"2018-06-02T10:16:57.966257Z","chain_id":"c277b118-58d9-4060-8dc5-a3b5898955cb","updated_at":"2018-06-02T10:16:57.966265Z","state":"active","type":"call","chain_symbol":"SPY","min_ticks":{"cutoff_price":"0.00","below_tick":"0.01","above_tick":"0.01"},"id":"8ada9799-6c34-4647-b3ee-b6c157745740"},{"issue_date":"2005-01-06","tradability":"tradable","strike_price":"298.0000","url":"https:\/\/api.robinhood.com\/options\/instruments\/637d839a-f3b3-45f9-91f4-b359c3ac80cb\/","expiration_date":"2018-06-29","created_at":"2018-06-02T10:16:57.964090Z","chain_id":"c277b118-58d9-4060-8dc5-a3b5898955cb","updated_at":"2018-06-02T10:16:57.964097Z","state":"active","type":"put","chain_symbol":"SPY","min_ticks":{"cutoff_price":"0.00","below_tick":"0.01","above_tick":"0.01"},"id":"637d839a-f3b3-45f9-91f4-b359c3ac80cb"}],"next":null}`,
-	apiURL + oAuthUpgradeURI:                                            `{"token_type":"Bearer","access_token":"btok","expires_in":300,"refresh_token":"reftok","scope":"web_limited"}`,
+	apiURL + oAuthUpgradeURI: `{"token_type":"Bearer","access_token":"btok","expires_in":300,"refresh_token":"reftok","scope":"web_limited"}`,
 	apiURL + marketOptionsURI + "8ada9799-6c34-4647-b3ee-b6c157745740/": `{"adjusted_mark_price":"28.3500","ask_price":"28.4700","ask_size":30,"bid_price":"28.2200","bid_size":35,"break_even_price":"269.6500","high_price":null,"instrument":"https://api.robinhood.com/options/instruments/637d839a-f3b3-45f9-91f4-b359c3ac80cb/","last_trade_price":null,"last_trade_size":null,"low_price":null,"mark_price":"28.3450","open_interest":0,"previous_close_date":"2018-06-22","previous_close_price":"23.2500","volume":0,"chance_of_profit_long":"0.5072","chance_of_profit_short":"0.4927","delta":"-0.9864","gamma":"0.0028","implied_volatility":"0.4268","rho":"-0.0322","theta":"-0.0400","vega":"0.0097"}`,
 }
 
@@ -47,8 +51,103 @@ func TestOptions(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	want := Option{"SPY", 296, exp, "call", 28.22, 28.47, 0, 28.35, 0, 0.4268, Instrument("https://api.robinhood.com/options/instruments/637d839a-f3b3-45f9-91f4-b359c3ac80cb/")}
+	prevClose, err := time.Parse("2006-01-02", "2018-06-22")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Option{
+		Symbol:              "SPY",
+		Strike:              296,
+		Expiration:          exp,
+		Type:                Call,
+		Bid:                 28.22,
+		Ask:                 28.47,
+		MarketPrice:         28.35,
+		IV:                  0.4268,
+		BidSize:             35,
+		AskSize:             30,
+		Mark:                28.345,
+		PreviousClose:       23.25,
+		PreviousCloseDate:   prevClose,
+		BreakEven:           269.65,
+		ChanceOfProfitLong:  0.5072,
+		ChanceOfProfitShort: 0.4927,
+		Greeks: Greeks{
+			Delta: -0.9864,
+			Gamma: 0.0028,
+			Theta: -0.04,
+			Vega:  0.0097,
+			Rho:   -0.0322,
+		},
+		instrument: Instrument("https://api.robinhood.com/options/instruments/637d839a-f3b3-45f9-91f4-b359c3ac80cb/"),
+	}
 	if got != want {
 		t.Fatalf("want = %v, got = %v", want, got)
 	}
 }
+
+func TestOptionsBatch(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	var mu sync.Mutex
+	tokens, calls := 0, 0
+	httpmock.RegisterResponder("POST", apiURL+oAuthUpgradeURI, func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		tokens++
+		return httpmock.NewStringResponse(200, options[apiURL+oAuthUpgradeURI]), nil
+	})
+	httpmock.RegisterResponder("GET", apiURL+marketOptionsURI, func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		calls++
+		mu.Unlock()
+		if req.Header.Get("Authorization") != "Bearer btok" {
+			return httpmock.NewStringResponse(401, `{"detail":"unauthorized"}`), nil
+		}
+		instruments := strings.Split(req.URL.Query().Get("instruments"), ",")
+		if len(instruments) > maxOptionsPerRequest {
+			return httpmock.NewStringResponse(400, `{"detail":"too many instruments"}`), nil
+		}
+		var results []string
+		// Reply in reverse order to check results are matched by instrument.
+		for i := len(instruments) - 1; i >= 0; i-- {
+			id := Instrument(instruments[i]).GetID()
+			if id == "id-13" {
+				results = append(results, "null")
+				continue
+			}
+			results = append(results, fmt.Sprintf(`{"adjusted_mark_price":"1.0000","ask_price":"1.1000","bid_price":"0.9000","instrument":"%s","delta":"0.%s","volume":7}`, instruments[i], strings.TrimPrefix(id, "id-")))
+		}
+		return httpmock.NewStringResponse(200, `{"results":[`+strings.Join(results, ",")+`]}`), nil
+	})
+
+	var chains []Chain
+	for i := 0; i < 100; i++ {
+		chains = append(chains, Chain{Symbol: "SPY", Strike: float64(200 + i), Type: Call, id: fmt.Sprintf("id-%d", i)})
+	}
+	c := Client{Token: "token"}
+	got, err := c.Options(chains)
+	missing, ok := err.(*MissingMarketDataError)
+	if !ok || len(missing.Chains) != 1 || missing.Chains[0] != chains[13] {
+		t.Fatalf("err = %v, want missing market data for chain 13", err)
+	}
+	if len(got) != len(chains) {
+		t.Fatalf("len(got) = %d, want %d", len(got), len(chains))
+	}
+	for i, o := range got {
+		if i == 13 {
+			if o.Symbol != "" {
+				t.Errorf("got[13] = %+v, want zero Option", o)
+			}
+			continue
+		}
+		delta, _ := strconv.ParseFloat(fmt.Sprintf("0.%d", i), 64)
+		if o.Strike != chains[i].Strike || o.Bid != 0.9 || o.Volume != 7 || o.Greeks.Delta != delta {
+			t.Errorf("got[%d] = %+v, want strike %v and delta %v", i, o, chains[i].Strike, delta)
+		}
+	}
+	if calls != 3 || tokens != 1 {
+		t.Errorf("calls = %d, tokens = %d; want 3 and 1", calls, tokens)
+	}
+}
@@ -0,0 +1,174 @@
+package robinhood
+
+// This file deals with snapshots of all the options of an underlying symbol.
+
+import (
+	"log"
+	"math"
+	"sort"
+	"time"
+)
+
+// OptionChainRequest selects what an OptionChain snapshot includes.
+type OptionChainRequest struct {
+	// From and To limit the snapshot to expirations within [From, To]. A zero
+	// From or To leaves that end of the range open.
+	From time.Time
+	To   time.Time
+
+	// MarketData requests a quote for every contract in the snapshot. This
+	// requires a bearer token.
+	MarketData bool
+}
+
+// OptionChain is a snapshot of the tradable options of an underlying symbol
+// across several expirations.
+type OptionChain struct {
+	Symbol string
+
+	// Expirations are the expiration dates in the snapshot, in order.
+	Expirations []time.Time
+
+	// Chains are all contracts in the snapshot, ordered by expiration, then by
+	// strike, with calls before puts.
+	Chains []Chain
+
+	// Options are the quotes for Chains, aligned to them, if market data was
+	// requested. Contracts the server has no market data for have a zero
+	// Option.
+	Options []Option
+
+	index map[chainKey]int // Index into Chains.
+}
+
+// chainKey identifies a contract within an OptionChain. Strikes are in tenths
+// of a cent so that they can be compared exactly.
+type chainKey struct {
+	expiration string
+	strike     int64
+	typ        OptionType
+}
+
+func newChainKey(expiration time.Time, strike float64, typ OptionType) chainKey {
+	return chainKey{
+		expiration: expiration.Format(dateFormat),
+		strike:     int64(math.Round(strike * 1000)),
+		typ:        typ,
+	}
+}
+
+// OptionChain returns a snapshot of the options of symbol that expire within
+// the requested range, fetched with as few requests as possible.
+func (c *Client) OptionChain(symbol string, req OptionChainRequest) (*OptionChain, error) {
+	exp, err := c.expirations(symbol)
+	if err != nil {
+		return nil, err
+	}
+	oc := &OptionChain{
+		Symbol: symbol,
+		index:  make(map[chainKey]int),
+	}
+	var dates []string
+	for _, date := range exp.Expirations {
+		e, err := time.Parse(dateFormat, date)
+		if err != nil {
+			log.Printf("Error converting expiration %s: %s", date, err)
+			continue
+		}
+		if (!req.From.IsZero() && e.Before(truncateDate(req.From))) || (!req.To.IsZero() && e.After(req.To)) {
+			continue
+		}
+		dates = append(dates, date)
+		oc.Expirations = append(oc.Expirations, e)
+	}
+	if len(dates) == 0 {
+		return oc, nil
+	}
+	sort.Slice(oc.Expirations, func(i, j int) bool { return oc.Expirations[i].Before(oc.Expirations[j]) })
+
+	chains, err := c.chains(symbol, dates)
+	if err != nil {
+		return nil, err
+	}
+	for _, ch := range chains {
+		chain, err := newChain(symbol, ch)
+		if err != nil {
+			log.Print(err)
+			continue
+		}
+		oc.Chains = append(oc.Chains, chain)
+	}
+	sort.Slice(oc.Chains, func(i, j int) bool {
+		a, b := oc.Chains[i], oc.Chains[j]
+		if !a.Expiration.Equal(b.Expiration) {
+			return a.Expiration.Before(b.Expiration)
+		}
+		if a.Strike != b.Strike {
+			return a.Strike < b.Strike
+		}
+		return a.Type < b.Type
+	})
+	for i, ch := range oc.Chains {
+		oc.index[newChainKey(ch.Expiration, ch.Strike, ch.Type)] = i
+	}
+
+	if req.MarketData {
+		oc.Options, err = c.Options(oc.Chains)
+		if _, ok := err.(*MissingMarketDataError); err != nil && !ok {
+			return nil, err
+		}
+	}
+	return oc, nil
+}
+
+// Chain returns the contract with the given expiration, strike and type.
+func (oc *OptionChain) Chain(expiration time.Time, strike float64, typ OptionType) (Chain, bool) {
+	i, ok := oc.index[newChainKey(expiration, strike, typ)]
+	if !ok {
+		return Chain{}, false
+	}
+	return oc.Chains[i], true
+}
+
+// Option returns the quote of the contract with the given expiration, strike
+// and type. It returns false if the contract is not in the snapshot or has no
+// market data.
+func (oc *OptionChain) Option(expiration time.Time, strike float64, typ OptionType) (Option, bool) {
+	i, ok := oc.index[newChainKey(expiration, strike, typ)]
+	if !ok || i >= len(oc.Options) || oc.Options[i].Symbol == "" {
+		return Option{}, false
+	}
+	return oc.Options[i], true
+}
+
+// ByExpiration returns the contracts expiring on the given date, ordered by
+// strike.
+func (oc *OptionChain) ByExpiration(expiration time.Time) []Chain {
+	date := expiration.Format(dateFormat)
+	start := sort.Search(len(oc.Chains), func(i int) bool {
+		return oc.Chains[i].Expiration.Format(dateFormat) >= date
+	})
+	end := start
+	for end < len(oc.Chains) && oc.Chains[end].Expiration.Format(dateFormat) == date {
+		end++
+	}
+	return oc.Chains[start:end]
+}
+
+// Strikes returns the distinct strikes available on the given expiration
+// date, in increasing order.
+func (oc *OptionChain) Strikes(expiration time.Time) []float64 {
+	var strikes []float64
+	for _, ch := range oc.ByExpiration(expiration) {
+		if len(strikes) == 0 || strikes[len(strikes)-1] != ch.Strike {
+			strikes = append(strikes, ch.Strike)
+		}
+	}
+	return strikes
+}
+
+// truncateDate returns the midnight UTC of t's date, the way expiration dates
+// are represented.
+func truncateDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
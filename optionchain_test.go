@@ -0,0 +1,109 @@
+package robinhood
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	httpmock "gopkg.in/jarcoal/httpmock.v1"
+)
+
+var optionChainReplies = map[string]string{
+	apiURL + instrumentsURI + "?symbol=SPY":                                            options[apiURL+instrumentsURI+"?symbol=SPY"],
+	apiURL + chainsURI + "?equity_instrument_ids=8f92e76f-1e0e-4478-8580-16a6ffcfaef5": options[apiURL+chainsURI+"?equity_instrument_ids=8f92e76f-1e0e-4478-8580-16a6ffcfaef5"],
+	apiURL + optionsURI + "?chain_id=c277b118-58d9-4060-8dc5-a3b5898955cb&expiration_dates=2018-06-29,2018-07-02&state=active&tradability=tradable": `{"previous":null,"results":[` +
+		`{"strike_price":"298.0000","expiration_date":"2018-07-02","chain_id":"c277b118-58d9-4060-8dc5-a3b5898955cb","type":"call","chain_symbol":"SPY","id":"id-0702-298-call"},` +
+		`{"strike_price":"298.0000","expiration_date":"2018-06-29","chain_id":"c277b118-58d9-4060-8dc5-a3b5898955cb","type":"put","chain_symbol":"SPY","id":"id-0629-298-put"},` +
+		`{"strike_price":"296.0000","expiration_date":"2018-06-29","chain_id":"c277b118-58d9-4060-8dc5-a3b5898955cb","type":"call","chain_symbol":"SPY","id":"id-0629-296-call"},` +
+		`{"strike_price":"298.0000","expiration_date":"2018-06-29","chain_id":"c277b118-58d9-4060-8dc5-a3b5898955cb","type":"call","chain_symbol":"SPY","id":"id-0629-298-call"}` +
+		`],"next":null}`,
+	apiURL + oAuthUpgradeURI: options[apiURL+oAuthUpgradeURI],
+	apiURL + marketOptionsURI + "?instruments=" + apiURL + optionsURI + "id-0629-296-call/," + apiURL + optionsURI + "id-0629-298-call/," + apiURL + optionsURI + "id-0629-298-put/," + apiURL + optionsURI + "id-0702-298-call/": `{"results":[` +
+		`{"adjusted_mark_price":"1.5000","ask_price":"1.6000","bid_price":"1.4000","instrument":"` + apiURL + optionsURI + `id-0629-298-put/","delta":"-0.5000"},` +
+		`{"adjusted_mark_price":"3.5000","ask_price":"3.6000","bid_price":"3.4000","instrument":"` + apiURL + optionsURI + `id-0629-296-call/","delta":"0.6000"},` +
+		`null,` +
+		`{"adjusted_mark_price":"2.5000","ask_price":"2.6000","bid_price":"2.4000","instrument":"` + apiURL + optionsURI + `id-0702-298-call/","delta":"0.4500"}]}`,
+}
+
+func TestOptionChain(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	calls := 0
+	for url, reply := range optionChainReplies {
+		reply := reply
+		method := "GET"
+		if strings.Contains(url, oAuthUpgradeURI) {
+			method = "POST"
+		}
+		httpmock.RegisterResponder(method, url, func(req *http.Request) (*http.Response, error) {
+			calls++
+			return httpmock.NewStringResponse(200, reply), nil
+		})
+	}
+
+	c := Client{Token: "token"}
+	date := func(s string) time.Time {
+		d, err := time.Parse(dateFormat, s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+	// From is in the middle of a day; expirations on that date are included.
+	oc, err := c.OptionChain("SPY", OptionChainRequest{
+		From:       date("2018-06-29").Add(15 * time.Hour),
+		To:         date("2018-07-02"),
+		MarketData: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(oc.Expirations) != 2 || !oc.Expirations[0].Equal(date("2018-06-29")) || !oc.Expirations[1].Equal(date("2018-07-02")) {
+		t.Fatalf("Expirations = %v", oc.Expirations)
+	}
+	wantIDs := []string{"id-0629-296-call", "id-0629-298-call", "id-0629-298-put", "id-0702-298-call"}
+	if len(oc.Chains) != len(wantIDs) || len(oc.Options) != len(wantIDs) {
+		t.Fatalf("len(Chains) = %d, len(Options) = %d; want %d", len(oc.Chains), len(oc.Options), len(wantIDs))
+	}
+	for i, id := range wantIDs {
+		if oc.Chains[i].id != id {
+			t.Errorf("Chains[%d].id = %s, want %s", i, oc.Chains[i].id, id)
+		}
+	}
+
+	ch, ok := oc.Chain(date("2018-06-29"), 298, Put)
+	if !ok || ch.id != "id-0629-298-put" {
+		t.Errorf("Chain(06-29, 298, put) = %v, %v", ch, ok)
+	}
+	if _, ok := oc.Chain(date("2018-06-29"), 297, Put); ok {
+		t.Error("found a chain for a strike that doesn't exist")
+	}
+	o, ok := oc.Option(date("2018-06-29"), 296, Call)
+	if !ok || o.Bid != 3.4 || o.Greeks.Delta != 0.6 {
+		t.Errorf("Option(06-29, 296, call) = %+v, %v", o, ok)
+	}
+	if _, ok := oc.Option(date("2018-06-29"), 298, Call); ok {
+		t.Error("found market data for a contract without it")
+	}
+	if got := oc.ByExpiration(date("2018-07-02")); len(got) != 1 || got[0].id != "id-0702-298-call" {
+		t.Errorf("ByExpiration(07-02) = %v", got)
+	}
+	if got := oc.Strikes(date("2018-06-29")); len(got) != 2 || got[0] != 296 || got[1] != 298 {
+		t.Errorf("Strikes(06-29) = %v", got)
+	}
+	if calls != 5 {
+		t.Errorf("calls = %d, want 5", calls)
+	}
+
+	// The chain ID is reused: only the contracts and their quotes are fetched
+	// again.
+	_, err = c.OptionChain("SPY", OptionChainRequest{From: date("2018-06-29"), To: date("2018-07-02"), MarketData: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if calls != 7 {
+		t.Errorf("calls = %d, want 7", calls)
+	}
+}
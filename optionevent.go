@@ -0,0 +1,127 @@
+package robinhood
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// This file deals with expirations, assignments and exercises of options.
+
+// OptionEvent is the expiration, assignment or exercise of an option position
+// of the user.
+type OptionEvent struct {
+	ID      string
+	Account string // URL of the account.
+	// Type is "expiration", "assignment" or "exercise".
+	Type     string
+	Chain    Chain
+	Quantity float64 // Contracts.
+	// CashAmount is the cash received, or paid if negative, for the stock
+	// delivered.
+	CashAmount      float64
+	UnderlyingPrice float64
+	Date            time.Time
+	// State is "pending", "confirmed", "completed", etc.
+	State string
+	// Components are the stock trades the event resulted in. Empty for
+	// expirations.
+	Components []EventComponent
+	CreatedAt  time.Time
+}
+
+// EventComponent is a stock trade resulting from an option event.
+type EventComponent struct {
+	Symbol   string
+	Side     Side // Buy or Sell.
+	Quantity float64
+	Price    float64
+}
+
+// OptionEvents returns the expirations, assignments and exercises of the
+// user's option positions, of every account, most recent first.
+func (c *Client) OptionEvents() ([]OptionEvent, error) {
+	resp, err := c.paginatedGet(optionEventsURI)
+	if err != nil {
+		return nil, err
+	}
+	var es []optionEvent
+	err = json.Unmarshal(resp, &es)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]OptionEvent, len(es))
+	for i, e := range es {
+		quantity, err := parseFloat64(e.Quantity, nil)
+		cash, err := parseOptionalFloat64(e.TotalCashAmount, err)
+		underlying, err := parseOptionalFloat64(e.UnderlyingPrice, err)
+		created, err := parseTime(e.CreatedAt, err)
+		var date time.Time
+		if err == nil {
+			date, err = time.Parse(dateFormat, e.EventDate)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error parsing option event %s: %v", e.ID, err)
+		}
+		if e.Direction == "debit" {
+			cash = -cash
+		}
+		events[i] = OptionEvent{
+			ID:              e.ID,
+			Account:         e.Account,
+			Type:            e.Type,
+			Quantity:        quantity,
+			CashAmount:      cash,
+			UnderlyingPrice: underlying,
+			Date:            date,
+			State:           e.State,
+			CreatedAt:       created,
+		}
+		for _, ec := range e.EquityComponents {
+			side, err := parseSide(ec.Side, "")
+			quantity, err := parseFloat64(ec.Quantity, err)
+			price, err := parseFloat64(ec.Price, err)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing option event %s: %v", e.ID, err)
+			}
+			events[i].Components = append(events[i].Components, EventComponent{
+				Symbol:   ec.Symbol,
+				Side:     side,
+				Quantity: quantity,
+				Price:    price,
+			})
+		}
+	}
+	err = c.forEach(len(es), func(i int) error {
+		ch, err := c.optionInstrument(es[i].ChainSymbol, Instrument(es[i].Option))
+		events[i].Chain = ch
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return events, nil
+}
+
+type optionEvent struct {
+	ID               string           `json:"id"`
+	Type             string           `json:"type"`
+	Account          string           `json:"account"`
+	Option           string           `json:"option"`
+	ChainSymbol      string           `json:"chain_symbol"`
+	Quantity         string           `json:"quantity"`
+	Direction        string           `json:"direction"` // "credit" or "debit"
+	TotalCashAmount  string           `json:"total_cash_amount"`
+	UnderlyingPrice  string           `json:"underlying_price"`
+	EventDate        string           `json:"event_date"`
+	State            string           `json:"state"`
+	CreatedAt        string           `json:"created_at"`
+	EquityComponents []eventComponent `json:"equity_components"`
+}
+
+type eventComponent struct {
+	Symbol   string `json:"symbol"`
+	Side     string `json:"side"`
+	Quantity string `json:"quantity"`
+	Price    string `json:"price"`
+}
@@ -0,0 +1,42 @@
+package robinhood
+
+import (
+	"testing"
+	"time"
+
+	httpmock "gopkg.in/jarcoal/httpmock.v1"
+)
+
+func TestOptionEvents(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", apiURL+optionEventsURI, httpmock.NewStringResponder(200, `{"previous":null,"results":[`+
+		`{"id":"ev2","type":"assignment","account":"https://api.robinhood.com/accounts/5RY82436/","option":"https://api.robinhood.com/options/instruments/short-put/","chain_symbol":"SPY","quantity":"1.0000","direction":"debit","total_cash_amount":"27000.00","underlying_price":"268.5000","event_date":"2018-07-20","state":"confirmed","created_at":"2018-07-21T02:00:00.000000Z","equity_components":[{"id":"c1","symbol":"SPY","side":"buy","quantity":"100.0000","price":"270.0000"}]},`+
+		`{"id":"ev1","type":"expiration","account":"https://api.robinhood.com/accounts/5RY82436/","option":"https://api.robinhood.com/options/instruments/long-put/","chain_symbol":"SPY","quantity":"1.0000","direction":"credit","total_cash_amount":null,"underlying_price":"268.5000","event_date":"2018-07-20","state":"confirmed","created_at":"2018-07-21T02:00:00.000000Z","equity_components":[]}`+
+		`],"next":null}`))
+	httpmock.RegisterResponder("GET", apiURL+optionsURI+"short-put/", httpmock.NewStringResponder(200,
+		`{"id":"short-put","strike_price":"270.0000","expiration_date":"2018-07-20","type":"put","chain_symbol":"SPY"}`))
+	httpmock.RegisterResponder("GET", apiURL+optionsURI+"long-put/", httpmock.NewStringResponder(200,
+		`{"id":"long-put","strike_price":"265.0000","expiration_date":"2018-07-20","type":"put","chain_symbol":"SPY"}`))
+
+	c := Client{Token: "token"}
+	events, err := c.OptionEvents()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 {
+		t.Fatalf("len(events) = %d, want 2", len(events))
+	}
+	assigned, expired := events[0], events[1]
+	if assigned.Type != "assignment" || assigned.Account != "https://api.robinhood.com/accounts/5RY82436/" || assigned.Chain.Strike != 270 || assigned.CashAmount != -27000 || !assigned.Date.Equal(time.Date(2018, 7, 20, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("assignment = %+v", assigned)
+	}
+	want := EventComponent{Symbol: "SPY", Side: Buy, Quantity: 100, Price: 270}
+	if len(assigned.Components) != 1 || assigned.Components[0] != want {
+		t.Errorf("components = %+v, want [%+v]", assigned.Components, want)
+	}
+	if expired.Type != "expiration" || expired.Chain.Strike != 265 || expired.CashAmount != 0 || len(expired.Components) != 0 {
+		t.Errorf("expiration = %+v", expired)
+	}
+}
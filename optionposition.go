@@ -0,0 +1,184 @@
+package robinhood
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/url"
+)
+
+// This file deals with option positions.
+
+// Direction is whether a position is long or short.
+type Direction int
+
+// Directions of a position.
+const (
+	Long Direction = iota + 1
+	Short
+)
+
+// String returns "long" or "short".
+func (d Direction) String() string {
+	switch d {
+	case Long:
+		return "long"
+	case Short:
+		return "short"
+	default:
+		return "invalid direction"
+	}
+}
+
+// OptionPosition is an open position in an option.
+type OptionPosition struct {
+	Chain     Chain
+	Direction Direction
+	Quantity  float64 // Number of contracts. Always positive.
+	// AveragePrice is the average price paid (or received, if short) per
+	// share of the underlying, like Option.MarketPrice. A contract is worth
+	// AveragePrice × Multiplier.
+	AveragePrice float64
+	Multiplier   float64 // Shares of the underlying per contract, usually 100.
+
+	// Option is the current market data for Chain. It is zero if the server
+	// has no market data for it.
+	Option Option
+	// MarketValue is the value of the position at Option.MarketPrice. It is
+	// negative for short positions.
+	MarketValue float64
+}
+
+// Cost returns the amount paid to open the position. It is negative for short
+// positions, for which a premium was received.
+func (p OptionPosition) Cost() float64 {
+	cost := p.AveragePrice * p.Quantity * p.Multiplier
+	if p.Direction == Short {
+		return -cost
+	}
+	return cost
+}
+
+// OptionPositions returns the open option positions of the client's account,
+// AccountID, or of every account if AccountID is empty, with their current
+// market data.
+//
+// If the server has no market data for some positions, their Option and
+// MarketValue are left zero and a *MissingMarketDataError is returned along
+// with all positions.
+func (c *Client) OptionPositions() ([]OptionPosition, error) {
+	parms := url.Values{}
+	parms.Set("nonzero", "true")
+	if c.AccountID != "" {
+		parms.Set("account_numbers", c.AccountID)
+	}
+	resp, err := c.paginatedGet(optionPosURI + "?" + parms.Encode())
+	if err != nil {
+		return nil, err
+	}
+	var all []optionPosition
+	err = json.Unmarshal(resp, &all)
+	if err != nil {
+		return nil, err
+	}
+	// In case the server ignores account_numbers.
+	var pos []optionPosition
+	for _, p := range all {
+		if c.inAccount(p.Account) {
+			pos = append(pos, p)
+		}
+	}
+
+	positions := make([]OptionPosition, len(pos))
+	chains := make([]Chain, len(pos))
+	for i, p := range pos {
+		avg, err := parseFloat64(p.AveragePrice, nil)
+		quantity, err := parseFloat64(p.Quantity, err)
+		multiplier, err := parseFloat64(p.Multiplier, err)
+		if err != nil {
+			return nil, err
+		}
+		dir := Long
+		switch p.Type {
+		case "long":
+		case "short":
+			dir = Short
+		default:
+			return nil, fmt.Errorf("unknown direction %q of option position %s", p.Type, p.ID)
+		}
+		if multiplier == 0 {
+			multiplier = 100
+		}
+		positions[i] = OptionPosition{
+			Direction:    dir,
+			Quantity:     math.Abs(quantity),
+			AveragePrice: math.Abs(avg) / multiplier,
+			Multiplier:   multiplier,
+		}
+	}
+	err = c.forEach(len(pos), func(i int) error {
+		ch, err := c.optionInstrument(pos[i].ChainSymbol, Instrument(pos[i].Option))
+		chains[i] = ch
+		positions[i].Chain = ch
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	opts, err := c.Options(chains)
+	if _, ok := err.(*MissingMarketDataError); err != nil && !ok {
+		return nil, err
+	}
+	for i, o := range opts {
+		positions[i].Option = o
+		value := o.MarketPrice * positions[i].Quantity * positions[i].Multiplier
+		if positions[i].Direction == Short {
+			value = -value
+		}
+		positions[i].MarketValue = value
+	}
+	return positions, err
+}
+
+// optionInstrument returns the chain of the option instrument u, an option on
+// symbol.
+func (c *Client) optionInstrument(symbol string, u Instrument) (Chain, error) {
+	c.init()
+	id := u.GetID()
+	if id == "" {
+		return Chain{}, fmt.Errorf("invalid option instrument URL %q", u)
+	}
+	if v, ok := c.optionChains.get("option:" + id); ok {
+		return v.(Chain), nil
+	}
+	resp, err := c.get(optionsURI + id + "/")
+	if err != nil {
+		return Chain{}, err
+	}
+	var ch chain
+	err = json.Unmarshal(resp, &ch)
+	if err != nil {
+		return Chain{}, err
+	}
+	if ch.ChainSymbol != "" {
+		symbol = ch.ChainSymbol
+	}
+	found, err := newChain(symbol, ch)
+	if err != nil {
+		return Chain{}, err
+	}
+	c.optionChains.set("option:"+id, found)
+	return found, nil
+}
+
+type optionPosition struct {
+	ID           string `json:"id"`
+	Account      string `json:"account"`
+	Option       string `json:"option"`
+	ChainSymbol  string `json:"chain_symbol"`
+	AveragePrice string `json:"average_price"`
+	Quantity     string `json:"quantity"`
+	Multiplier   string `json:"trade_value_multiplier"`
+	Type         string `json:"type"` // "long" or "short"
+}
@@ -0,0 +1,78 @@
+package robinhood
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	httpmock "gopkg.in/jarcoal/httpmock.v1"
+)
+
+var optionPositions = map[string]string{
+	apiURL + optionPosURI + "?account_numbers=account&nonzero=true": `{"previous":null,"results":[` +
+		`{"account":"https://api.robinhood.com/accounts/account/","average_price":"152.0000","chain_id":"c277b118-58d9-4060-8dc5-a3b5898955cb","chain_symbol":"SPY","id":"pos-1","option":"https://api.robinhood.com/options/instruments/8ada9799-6c34-4647-b3ee-b6c157745740/","type":"long","pending_buy_quantity":"0.0000","pending_sell_quantity":"0.0000","quantity":"2.0000","trade_value_multiplier":"100.0000"},` +
+		`{"account":"https://api.robinhood.com/accounts/account/","average_price":"-310.0000","chain_id":"c277b118-58d9-4060-8dc5-a3b5898955cb","chain_symbol":"SPY","id":"pos-2","option":"https://api.robinhood.com/options/instruments/637d839a-f3b3-45f9-91f4-b359c3ac80cb/","type":"short","pending_buy_quantity":"0.0000","pending_sell_quantity":"0.0000","quantity":"1.0000","trade_value_multiplier":"100.0000"},` +
+		`{"account":"https://api.robinhood.com/accounts/other/","average_price":"50.0000","chain_id":"c277b118-58d9-4060-8dc5-a3b5898955cb","chain_symbol":"SPY","id":"pos-3","option":"https://api.robinhood.com/options/instruments/unknown/","type":"long","pending_buy_quantity":"0.0000","pending_sell_quantity":"0.0000","quantity":"1.0000","trade_value_multiplier":"100.0000"}` +
+		`],"next":null}`,
+	apiURL + optionsURI + "8ada9799-6c34-4647-b3ee-b6c157745740/": `{"issue_date":"2005-01-06","tradability":"tradable","strike_price":"296.0000","url":"https:\/\/api.robinhood.com\/options\/instruments\/8ada9799-6c34-4647-b3ee-b6c157745740\/","expiration_date":"2018-06-29","chain_id":"c277b118-58d9-4060-8dc5-a3b5898955cb","state":"active","type":"call","chain_symbol":"SPY","id":"8ada9799-6c34-4647-b3ee-b6c157745740"}`,
+	apiURL + optionsURI + "637d839a-f3b3-45f9-91f4-b359c3ac80cb/": `{"issue_date":"2005-01-06","tradability":"tradable","strike_price":"298.0000","url":"https:\/\/api.robinhood.com\/options\/instruments\/637d839a-f3b3-45f9-91f4-b359c3ac80cb\/","expiration_date":"2018-06-29","chain_id":"c277b118-58d9-4060-8dc5-a3b5898955cb","state":"active","type":"put","chain_symbol":"SPY","id":"637d839a-f3b3-45f9-91f4-b359c3ac80cb"}`,
+	apiURL + oAuthUpgradeURI: `{"token_type":"Bearer","access_token":"btok","expires_in":300,"refresh_token":"reftok","scope":"web_limited"}`,
+	apiURL + marketOptionsURI + "?instruments=" + apiURL + optionsURI + "8ada9799-6c34-4647-b3ee-b6c157745740/," + apiURL + optionsURI + "637d839a-f3b3-45f9-91f4-b359c3ac80cb/": `{"results":[` +
+		`{"adjusted_mark_price":"1.2500","ask_price":"1.3000","bid_price":"1.2000","instrument":"https://api.robinhood.com/options/instruments/8ada9799-6c34-4647-b3ee-b6c157745740/","mark_price":"1.2500","implied_volatility":"0.1500","delta":"0.3000"},` +
+		`null]}`,
+}
+
+func TestOptionPositions(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	for url, reply := range optionPositions {
+		postOrGet := "GET"
+		if strings.Contains(url, oAuthUpgradeURI) {
+			postOrGet = "POST"
+		}
+		httpmock.RegisterResponder(postOrGet, url, httpmock.NewStringResponder(200, reply))
+	}
+
+	c := Client{
+		AccountID: "account",
+		Token:     "token",
+	}
+	positions, err := c.OptionPositions()
+	missing, ok := err.(*MissingMarketDataError)
+	if !ok || len(missing.Chains) != 1 || missing.Chains[0].Strike != 298 {
+		t.Fatalf("err = %v, want missing market data for the 298 put", err)
+	}
+	if len(positions) != 2 {
+		t.Fatalf("len(positions) = %d, want 2", len(positions))
+	}
+	exp := time.Date(2018, 6, 29, 0, 0, 0, 0, time.UTC)
+
+	long := positions[0]
+	if long.Chain.Symbol != "SPY" || long.Chain.Strike != 296 || long.Chain.Type != Call || !long.Chain.Expiration.Equal(exp) {
+		t.Errorf("long chain = %+v", long.Chain)
+	}
+	if long.Direction != Long || long.Quantity != 2 || long.AveragePrice != 1.52 || long.Multiplier != 100 {
+		t.Errorf("long = %+v", long)
+	}
+	if long.Cost() != 304 || long.MarketValue != 250 || long.Option.Greeks.Delta != 0.3 {
+		t.Errorf("long cost %v, value %v, option %+v", long.Cost(), long.MarketValue, long.Option)
+	}
+
+	short := positions[1]
+	if short.Chain.Strike != 298 || short.Chain.Type != Put || short.Direction != Short || short.Quantity != 1 || short.AveragePrice != 3.1 {
+		t.Errorf("short = %+v", short)
+	}
+	if short.Cost() != -310 || short.MarketValue != 0 {
+		t.Errorf("short cost %v, value %v", short.Cost(), short.MarketValue)
+	}
+
+	// Option instruments are cached.
+	before := httpmock.GetTotalCallCount()
+	if _, err := c.OptionPositions(); err == nil {
+		t.Fatal("expected missing market data error")
+	}
+	if got := httpmock.GetTotalCallCount() - before; got != 2 {
+		t.Errorf("second call made %d requests, want 2", got)
+	}
+}
@@ -0,0 +1,124 @@
+package robinhood
+
+// This file deals with option types and OCC option symbols.
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OptionType is the type of an option: a call or a put.
+type OptionType int
+
+// See description for OptionType. The zero OptionType is invalid; in a
+// ChainQuery it selects both calls and puts.
+const (
+	Call OptionType = iota + 1
+	Put
+)
+
+// ParseOptionType parses "call" or "put", in any case. "c" and "p" are also
+// accepted.
+func ParseOptionType(s string) (OptionType, error) {
+	switch strings.ToLower(s) {
+	case "call", "c":
+		return Call, nil
+	case "put", "p":
+		return Put, nil
+	}
+	return 0, fmt.Errorf("invalid option type %q", s)
+}
+
+// String implements Stringer.
+func (t OptionType) String() string {
+	switch t {
+	case Call:
+		return "call"
+	case Put:
+		return "put"
+	default:
+		return "invalid option type"
+	}
+}
+
+// MarshalText implements encoding.TextMarshaler. OptionTypes are marshalled
+// as "call" or "put", including in JSON.
+func (t OptionType) MarshalText() ([]byte, error) {
+	if t != Call && t != Put {
+		return nil, fmt.Errorf("invalid option type %d", int(t))
+	}
+	return []byte(t.String()), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.
+func (t *OptionType) UnmarshalText(text []byte) error {
+	typ, err := ParseOptionType(string(text))
+	if err != nil {
+		return err
+	}
+	*t = typ
+	return nil
+}
+
+// occLen is the length of an OCC symbol without its root: a six digit date, the
+// type and an eight digit strike.
+const occLen = 15
+
+// ParseOCC parses a standard OCC option symbol, such as
+// "SPY   180629C00296000", into a Chain. The root symbol may also be unpadded,
+// as in "SPY180629C00296000". The resulting Chain can't be quoted or traded
+// until it is resolved with Client.LookupChain.
+func ParseOCC(symbol string) (Chain, error) {
+	if len(symbol) <= occLen {
+		return Chain{}, fmt.Errorf("OCC symbol %q too short", symbol)
+	}
+	root := strings.TrimSpace(symbol[:len(symbol)-occLen])
+	rest := symbol[len(symbol)-occLen:]
+	if root == "" || len(root) > 6 {
+		return Chain{}, fmt.Errorf("invalid root in OCC symbol %q", symbol)
+	}
+	exp, err := time.Parse("060102", rest[:6])
+	if err != nil {
+		return Chain{}, fmt.Errorf("invalid expiration in OCC symbol %q: %v", symbol, err)
+	}
+	typ, err := ParseOptionType(rest[6:7])
+	if err != nil {
+		return Chain{}, fmt.Errorf("invalid type in OCC symbol %q: %v", symbol, err)
+	}
+	strike, err := strconv.ParseUint(rest[7:], 10, 64)
+	if err != nil {
+		return Chain{}, fmt.Errorf("invalid strike in OCC symbol %q: %v", symbol, err)
+	}
+	return Chain{
+		Strike:     float64(strike) / 1000,
+		Expiration: exp,
+		Symbol:     root,
+		Type:       typ,
+	}, nil
+}
+
+// OCC returns the standard OCC option symbol of ch, with the root symbol
+// padded to six characters, e.g. "SPY   180629C00296000".
+func (ch Chain) OCC() string {
+	typ := "C"
+	if ch.Type == Put {
+		typ = "P"
+	}
+	return fmt.Sprintf("%-6s%s%s%08d", ch.Symbol, ch.Expiration.Format("060102"), typ, int64(ch.Strike*1000+0.5))
+}
+
+// LookupChain returns the tradable chain matching the symbol, expiration,
+// strike and type of ch, such as one returned by ParseOCC.
+func (c *Client) LookupChain(ch Chain) (Chain, error) {
+	oc, err := c.OptionChain(ch.Symbol, OptionChainRequest{From: ch.Expiration, To: ch.Expiration})
+	if err != nil {
+		return Chain{}, err
+	}
+	found, ok := oc.Chain(ch.Expiration, ch.Strike, ch.Type)
+	if !ok {
+		return Chain{}, fmt.Errorf("no tradable option %s", ch.OCC())
+	}
+	return found, nil
+}
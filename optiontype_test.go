@@ -0,0 +1,92 @@
+package robinhood
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	httpmock "gopkg.in/jarcoal/httpmock.v1"
+)
+
+func TestOptionTypeJSON(t *testing.T) {
+	b, err := json.Marshal(Chain{Strike: 296, Symbol: "SPY", Type: Put})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(b), `"Type":"put"`) {
+		t.Errorf("Marshal = %s, want type put", b)
+	}
+	var ch Chain
+	err = json.Unmarshal([]byte(`{"Strike":296,"Symbol":"SPY","Type":"CALL"}`), &ch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ch.Type != Call {
+		t.Errorf("Type = %v, want call", ch.Type)
+	}
+	if err := json.Unmarshal([]byte(`{"Type":"straddle"}`), &ch); err == nil {
+		t.Error("expected error unmarshalling invalid option type")
+	}
+	if _, err := json.Marshal(Chain{}); err == nil {
+		t.Error("expected error marshalling invalid option type")
+	}
+}
+
+func TestOCC(t *testing.T) {
+	exp := time.Date(2018, 6, 29, 0, 0, 0, 0, time.UTC)
+	tests := []struct {
+		occ   string
+		chain Chain
+	}{
+		{"SPY   180629C00296000", Chain{Strike: 296, Expiration: exp, Symbol: "SPY", Type: Call}},
+		{"GOOGL 180629P01122500", Chain{Strike: 1122.5, Expiration: exp, Symbol: "GOOGL", Type: Put}},
+		{"F     180629P00011310", Chain{Strike: 11.31, Expiration: exp, Symbol: "F", Type: Put}},
+	}
+	for _, test := range tests {
+		got, err := ParseOCC(test.occ)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != test.chain {
+			t.Errorf("ParseOCC(%q) = %v, want %v", test.occ, got, test.chain)
+		}
+		if occ := test.chain.OCC(); occ != test.occ {
+			t.Errorf("OCC() = %q, want %q", occ, test.occ)
+		}
+	}
+	got, err := ParseOCC("SPY180629C00296000")
+	if err != nil || got != tests[0].chain {
+		t.Errorf("ParseOCC of unpadded symbol = %v, %v", got, err)
+	}
+	for _, bad := range []string{"", "C00296000", "SPY   18062XC00296000", "SPY   180629X00296000", "SPY   180629C0029600A", "TOOLONG180629C00296000"} {
+		if _, err := ParseOCC(bad); err == nil {
+			t.Errorf("ParseOCC(%q) succeeded, want error", bad)
+		}
+	}
+}
+
+func TestLookupChain(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	for url, reply := range options {
+		httpmock.RegisterResponder("GET", url, httpmock.NewStringResponder(200, reply))
+	}
+	c := Client{Token: "token"}
+	ch, err := ParseOCC("SPY   180629P00298000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := c.LookupChain(ch)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.id != "637d839a-f3b3-45f9-91f4-b359c3ac80cb" || got.Strike != 298 || got.Type != Put {
+		t.Errorf("LookupChain = %v", got)
+	}
+	ch.Type = Call
+	if _, err := c.LookupChain(ch); err == nil {
+		t.Error("expected error looking up a contract that doesn't exist")
+	}
+}
@@ -69,29 +69,22 @@ func (c *Client) Order(o Order) error {
 		return fmt.Errorf("price must never be zero or negative")
 	}
 	// Find the instrument.
-	quotes, err := c.quote([]string{o.Symbol})
+	inst, err := c.InstrumentBySymbol(o.Symbol)
 	if err != nil {
 		return err
 	}
-	if len(quotes) != 1 {
-		return fmt.Errorf("invalid quote returned for symbol %q: %v", o.Symbol, err)
+	// Instruments that are closing only can still be sold, to close a
+	// position, but not bought.
+	if o.Side == Buy && !inst.tradable() {
+		return fmt.Errorf("symbol %q can't be bought: tradability is %q", o.Symbol, inst.Tradability)
 	}
 	// Fetch account URL. This could be assembled from the appropriate URI pieces,
 	// but this way is safer against trivial endpoint changes.
-	accs, err := c.GetAccounts()
+	accountURL, err := c.accountURL()
 	if err != nil {
 		return err
 	}
-	accountURL := ""
-	for _, a := range accs {
-		if a.AccountNumber == c.AccountID {
-			accountURL = a.URL
-		}
-	}
-	if accountURL == "" {
-		return fmt.Errorf("invalid account number %s", c.AccountID)
-	}
-	instrument := quotes[0].Instrument
+	instrument := inst.URL
 	oType := "market"
 	if o.Type != Market {
 		oType = "limit"
@@ -0,0 +1,39 @@
+package robinhood
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+
+	httpmock "gopkg.in/jarcoal/httpmock.v1"
+)
+
+func TestOrderClosingOnly(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", apiURL+instrumentsURI+"?symbol=FB", httpmock.NewStringResponder(200, `{"previous":null,"results":[`+
+		`{"id":"fb","url":"https://api.robinhood.com/instruments/fb/","symbol":"FB","tradeable":false,"tradability":"position_closing_only"}`+
+		`],"next":null}`))
+	httpmock.RegisterResponder("GET", apiURL+accountsURI, httpmock.NewStringResponder(200, `{"previous":null,"results":[`+marginAccount+`],"next":null}`))
+	var sides []string
+	httpmock.RegisterResponder("POST", apiURL+ordersURI, func(req *http.Request) (*http.Response, error) {
+		if err := req.ParseForm(); err != nil {
+			return nil, err
+		}
+		sides = append(sides, req.PostForm.Get("side"))
+		return httpmock.NewStringResponse(200, `{"id":"o1","state":"queued"}`), nil
+	})
+
+	c := Client{AccountID: "5RY82436", Token: "token"}
+	err := c.Order(Order{Symbol: "FB", Quantity: 1, Type: Market, Side: Buy, Price: 150})
+	if err == nil || !strings.Contains(err.Error(), "position_closing_only") {
+		t.Errorf("buy: err = %v, want closing only error", err)
+	}
+	if err := c.Order(Order{Symbol: "FB", Quantity: 1, Type: Market, Side: Sell, Price: 150}); err != nil {
+		t.Fatalf("sell: %v", err)
+	}
+	if len(sides) != 1 || sides[0] != "sell" {
+		t.Errorf("orders posted = %v, want one sell", sides)
+	}
+}
@@ -0,0 +1,357 @@
+package robinhood
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// This file deals with the history of orders placed by the user.
+
+// Execution is a fill of part or all of an order.
+type Execution struct {
+	ID             string
+	Time           time.Time
+	Quantity       float64
+	Price          float64 // Per share, or per share of the underlying for options.
+	SettlementDate time.Time
+}
+
+// OrderInfo is a stock order placed by the user, with its fills.
+type OrderInfo struct {
+	ID         string
+	Account    string // URL of the account.
+	Instrument Instrument
+	Symbol     string // Empty if Instrument can't be resolved.
+	Side       Side   // Buy or Sell.
+	Type       OrderType
+	Duration   Duration
+	// State is "queued", "confirmed", "partially_filled", "filled",
+	// "cancelled", "rejected", "failed", etc.
+	State string
+
+	Quantity       float64 // Ordered.
+	FilledQuantity float64
+	Price          float64 // Limit price, zero for market orders.
+	StopPrice      float64
+	AveragePrice   float64 // Of the fills, zero if none.
+	Fees           float64
+
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+	Executions []Execution
+}
+
+// Orders returns all stock orders placed by the user, of every account, most
+// recent first.
+//
+// If the instruments of some orders can't be resolved, Orders returns all
+// orders along with an *UnresolvedInstrumentsError listing them; those orders
+// have no Symbol.
+func (c *Client) Orders() ([]OrderInfo, error) {
+	resp, err := c.paginatedGet(ordersURI)
+	if err != nil {
+		return nil, err
+	}
+	var os []orderInfo
+	err = json.Unmarshal(resp, &os)
+	if err != nil {
+		return nil, err
+	}
+	orders := make([]OrderInfo, len(os))
+	urls := make([]Instrument, len(os))
+	for i, o := range os {
+		side, err := parseSide(o.Side, "")
+		typ := Market
+		if o.Type == "limit" {
+			typ = Limit
+		}
+		if o.Trigger == "stop" {
+			typ = Stop
+			if o.Type == "limit" {
+				typ = StopLimit
+			}
+		}
+		duration := Day
+		if o.TimeInForce == "gtc" {
+			duration = GTC
+		}
+		quantity, err := parseFloat64(o.Quantity, err)
+		filled, err := parseOptionalFloat64(o.CumulativeQuantity, err)
+		price, err := parseOptionalFloat64(o.Price, err)
+		stop, err := parseOptionalFloat64(o.StopPrice, err)
+		avg, err := parseOptionalFloat64(o.AveragePrice, err)
+		fees, err := parseOptionalFloat64(o.Fees, err)
+		created, err := parseTime(o.CreatedAt, err)
+		updated, err := parseTime(o.UpdatedAt, err)
+		execs, err := newExecutions(o.Executions, err)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing order %s: %v", o.ID, err)
+		}
+		orders[i] = OrderInfo{
+			ID:             o.ID,
+			Account:        o.Account,
+			Instrument:     o.Instrument,
+			Side:           side,
+			Type:           typ,
+			Duration:       duration,
+			State:          o.State,
+			Quantity:       quantity,
+			FilledQuantity: filled,
+			Price:          price,
+			StopPrice:      stop,
+			AveragePrice:   avg,
+			Fees:           fees,
+			CreatedAt:      created,
+			UpdatedAt:      updated,
+			Executions:     execs,
+		}
+		urls[i] = o.Instrument
+	}
+	insts, unresolved := c.resolveInstruments(urls)
+	for i, inst := range insts {
+		orders[i].Symbol = inst.Symbol
+	}
+	if unresolved != nil {
+		return orders, unresolved
+	}
+	return orders, nil
+}
+
+// OptionOrderInfo is an option order placed by the user, of one or more legs.
+type OptionOrderInfo struct {
+	ID      string
+	Account string // URL of the account.
+	Symbol  string // Of the underlying.
+	// State is "queued", "confirmed", "partially_filled", "filled",
+	// "cancelled", "rejected", "failed", etc.
+	State    string
+	Type     OrderType
+	Duration Duration
+
+	Quantity       float64 // Of the strategy, in contracts per leg ratio.
+	FilledQuantity float64
+	Price          float64 // Limit price per share, zero for market orders.
+	// Premium is the net premium per share of the strategy, paid if Debit
+	// or received if not.
+	Premium float64
+	Debit   bool
+	// Fees are the regulatory and contract fees of the whole order.
+	Fees float64
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	Legs      []OptionOrderLeg
+}
+
+// OptionOrderLeg is one option of an option order.
+type OptionOrderLeg struct {
+	Option Instrument // URL of the option.
+	// Chain is the option, zero (with no Type) if Option can't be resolved.
+	Chain         Chain
+	Side          Side // BuyToOpen, BuyToClose, SellToOpen or SellToClose.
+	RatioQuantity float64
+	Executions    []Execution
+}
+
+// OptionOrders returns all option orders placed by the user, of every
+// account, most recent first.
+//
+// If the options of some legs can't be resolved, OptionOrders returns all
+// orders along with an *UnresolvedInstrumentsError listing them; those legs
+// have no Chain.
+func (c *Client) OptionOrders() ([]OptionOrderInfo, error) {
+	resp, err := c.paginatedGet(optionOrdersURI)
+	if err != nil {
+		return nil, err
+	}
+	var os []optionOrderInfo
+	err = json.Unmarshal(resp, &os)
+	if err != nil {
+		return nil, err
+	}
+	orders := make([]OptionOrderInfo, len(os))
+	type legRef struct{ order, leg int }
+	var legs []legRef
+	for i, o := range os {
+		typ := Market
+		if o.Type == "limit" {
+			typ = Limit
+		}
+		duration := Day
+		if o.TimeInForce == "gtc" {
+			duration = GTC
+		}
+		quantity, err := parseFloat64(o.Quantity, nil)
+		filled, err := parseOptionalFloat64(o.ProcessedQuantity, err)
+		price, err := parseOptionalFloat64(o.Price, err)
+		premium, err := parseOptionalFloat64(o.ProcessedPremium, err)
+		regulatory, err := parseOptionalFloat64(o.RegulatoryFees, err)
+		contract, err := parseOptionalFloat64(o.ContractFees, err)
+		created, err := parseTime(o.CreatedAt, err)
+		updated, err := parseTime(o.UpdatedAt, err)
+		if err != nil {
+			return nil, fmt.Errorf("error parsing option order %s: %v", o.ID, err)
+		}
+		if filled != 0 {
+			// The processed premium is for the whole order, in dollars.
+			premium /= filled * 100
+		}
+		orders[i] = OptionOrderInfo{
+			ID:             o.ID,
+			Account:        o.Account,
+			Symbol:         o.ChainSymbol,
+			State:          o.State,
+			Type:           typ,
+			Duration:       duration,
+			Quantity:       quantity,
+			FilledQuantity: filled,
+			Price:          price,
+			Premium:        premium,
+			Debit:          o.Direction == "debit",
+			Fees:           regulatory + contract,
+			CreatedAt:      created,
+			UpdatedAt:      updated,
+			Legs:           make([]OptionOrderLeg, len(o.Legs)),
+		}
+		for j, l := range o.Legs {
+			side, err := parseSide(l.Side, l.PositionEffect)
+			ratio, err := parseOptionalFloat64(l.RatioQuantity.String(), err)
+			execs, err := newExecutions(l.Executions, err)
+			if err != nil {
+				return nil, fmt.Errorf("error parsing option order %s: %v", o.ID, err)
+			}
+			orders[i].Legs[j] = OptionOrderLeg{Option: Instrument(l.Option), Side: side, RatioQuantity: ratio, Executions: execs}
+			legs = append(legs, legRef{i, j})
+		}
+	}
+	errs := make([]error, len(legs))
+	c.forEach(len(legs), func(k int) error {
+		o, l := legs[k].order, legs[k].leg
+		orders[o].Legs[l].Chain, errs[k] = c.optionInstrument(os[o].ChainSymbol, orders[o].Legs[l].Option)
+		return nil
+	})
+	var unresolved *UnresolvedInstrumentsError
+	failed := make(map[Instrument]bool)
+	for k, err := range errs {
+		u := orders[legs[k].order].Legs[legs[k].leg].Option
+		if err == nil || failed[u] {
+			continue
+		}
+		failed[u] = true
+		if unresolved == nil {
+			unresolved = &UnresolvedInstrumentsError{}
+		}
+		unresolved.Instruments = append(unresolved.Instruments, u)
+		unresolved.Errs = append(unresolved.Errs, err)
+	}
+	if unresolved != nil {
+		return orders, unresolved
+	}
+	return orders, nil
+}
+
+// parseSide parses the side of an order, given as "buy" or "sell", and for
+// options its position effect, "open" or "close".
+func parseSide(side, effect string) (Side, error) {
+	switch side + " " + effect {
+	case "buy ":
+		return Buy, nil
+	case "sell ":
+		return Sell, nil
+	case "buy open":
+		return BuyToOpen, nil
+	case "buy close":
+		return BuyToClose, nil
+	case "sell open":
+		return SellToOpen, nil
+	case "sell close":
+		return SellToClose, nil
+	default:
+		return 0, fmt.Errorf("unknown side %q %q", side, effect)
+	}
+}
+
+// newExecutions converts es to the external format. Like parseFloat64, it
+// returns prevErr if not nil.
+func newExecutions(es []execution, prevErr error) ([]Execution, error) {
+	if prevErr != nil {
+		return nil, prevErr
+	}
+	var execs []Execution
+	for _, e := range es {
+		ts, err := parseTime(e.Timestamp, nil)
+		quantity, err := parseFloat64(e.Quantity, err)
+		price, err := parseFloat64(e.Price, err)
+		var settlement time.Time
+		if e.SettlementDate != "" && err == nil {
+			settlement, err = time.Parse(dateFormat, e.SettlementDate)
+		}
+		if err != nil {
+			return nil, err
+		}
+		execs = append(execs, Execution{
+			ID:             e.ID,
+			Time:           ts,
+			Quantity:       quantity,
+			Price:          price,
+			SettlementDate: settlement,
+		})
+	}
+	return execs, nil
+}
+
+type orderInfo struct {
+	ID                 string      `json:"id"`
+	Account            string      `json:"account"`
+	Instrument         Instrument  `json:"instrument"`
+	Side               string      `json:"side"`
+	Type               string      `json:"type"`
+	Trigger            string      `json:"trigger"`
+	TimeInForce        string      `json:"time_in_force"`
+	State              string      `json:"state"`
+	Quantity           string      `json:"quantity"`
+	CumulativeQuantity string      `json:"cumulative_quantity"`
+	Price              string      `json:"price"`
+	StopPrice          string      `json:"stop_price"`
+	AveragePrice       string      `json:"average_price"`
+	Fees               string      `json:"fees"`
+	CreatedAt          string      `json:"created_at"`
+	UpdatedAt          string      `json:"updated_at"`
+	Executions         []execution `json:"executions"`
+}
+
+type optionOrderInfo struct {
+	ID                string           `json:"id"`
+	Account           string           `json:"account"`
+	ChainSymbol       string           `json:"chain_symbol"`
+	State             string           `json:"state"`
+	Type              string           `json:"type"`
+	TimeInForce       string           `json:"time_in_force"`
+	Direction         string           `json:"direction"` // "debit" or "credit"
+	Quantity          string           `json:"quantity"`
+	ProcessedQuantity string           `json:"processed_quantity"`
+	Price             string           `json:"price"`
+	ProcessedPremium  string           `json:"processed_premium"`
+	RegulatoryFees    string           `json:"regulatory_fees"`
+	ContractFees      string           `json:"contract_fees"`
+	CreatedAt         string           `json:"created_at"`
+	UpdatedAt         string           `json:"updated_at"`
+	Legs              []optionOrderLeg `json:"legs"`
+}
+
+type optionOrderLeg struct {
+	Option         string      `json:"option"`
+	Side           string      `json:"side"`            // "buy" or "sell"
+	PositionEffect string      `json:"position_effect"` // "open" or "close"
+	RatioQuantity  json.Number `json:"ratio_quantity"`
+	Executions     []execution `json:"executions"`
+}
+
+type execution struct {
+	ID             string `json:"id"`
+	Timestamp      string `json:"timestamp"`
+	Quantity       string `json:"quantity"`
+	Price          string `json:"price"`
+	SettlementDate string `json:"settlement_date"`
+}
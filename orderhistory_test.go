@@ -0,0 +1,116 @@
+package robinhood
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	httpmock "gopkg.in/jarcoal/httpmock.v1"
+)
+
+func TestOrders(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", apiURL+ordersURI, httpmock.NewStringResponder(200, `{"previous":null,"results":[`+
+		`{"id":"o2","account":"https://api.robinhood.com/accounts/5RY82436/","instrument":"https://api.robinhood.com/instruments/aapl/","side":"sell","type":"limit","trigger":"stop","time_in_force":"gtc","state":"filled","quantity":"10.00000","cumulative_quantity":"10.00000","price":"190.00","stop_price":"191.00","average_price":"190.50","fees":"0.02","created_at":"2018-06-20T14:00:00.000000Z","updated_at":"2018-06-20T14:05:00.000000Z","executions":[`+
+		`{"id":"e2","timestamp":"2018-06-20T14:01:00.000000Z","quantity":"4.00000","price":"190.00","settlement_date":"2018-06-22"},`+
+		`{"id":"e3","timestamp":"2018-06-20T14:02:00.000000Z","quantity":"6.00000","price":"190.83333","settlement_date":"2018-06-22"}]},`+
+		`{"id":"o1","account":"https://api.robinhood.com/accounts/5RY82436/","instrument":"https://api.robinhood.com/instruments/aapl/","side":"buy","type":"market","trigger":"immediate","time_in_force":"gfd","state":"filled","quantity":"10.00000","cumulative_quantity":"10.00000","price":null,"stop_price":null,"average_price":"180.00","fees":"0.00","created_at":"2018-01-02T15:00:00.000000Z","updated_at":"2018-01-02T15:00:01.000000Z","executions":[`+
+		`{"id":"e1","timestamp":"2018-01-02T15:00:01.000000Z","quantity":"10.00000","price":"180.00","settlement_date":"2018-01-04"}]}`+
+		`],"next":null}`))
+	httpmock.RegisterResponder("GET", apiURL+instrumentsURI+"aapl/", httpmock.NewStringResponder(200,
+		`{"id":"aapl","url":"https://api.robinhood.com/instruments/aapl/","symbol":"AAPL","simple_name":"Apple","tradeable":true}`))
+
+	c := Client{Token: "token"}
+	orders, err := c.Orders()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orders) != 2 {
+		t.Fatalf("len(orders) = %d, want 2", len(orders))
+	}
+	sell, buy := orders[0], orders[1]
+	if sell.Symbol != "AAPL" || sell.Side != Sell || sell.Type != StopLimit || sell.Duration != GTC || sell.StopPrice != 191 || sell.Fees != 0.02 {
+		t.Errorf("sell = %+v", sell)
+	}
+	if len(sell.Executions) != 2 || sell.Executions[1].Quantity != 6 {
+		t.Errorf("sell executions = %+v", sell.Executions)
+	}
+	want := Execution{
+		ID:             "e1",
+		Time:           time.Date(2018, 1, 2, 15, 0, 1, 0, time.UTC),
+		Quantity:       10,
+		Price:          180,
+		SettlementDate: time.Date(2018, 1, 4, 0, 0, 0, 0, time.UTC),
+	}
+	if buy.Side != Buy || buy.Type != Market || buy.Price != 0 || len(buy.Executions) != 1 || buy.Executions[0] != want {
+		t.Errorf("buy = %+v", buy)
+	}
+}
+
+func TestOptionOrders(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", apiURL+optionOrdersURI, httpmock.NewStringResponder(200, `{"previous":null,"results":[`+
+		`{"id":"oo1","account":"https://api.robinhood.com/accounts/5RY82436/","chain_symbol":"SPY","state":"filled","type":"limit","time_in_force":"gfd","direction":"credit","quantity":"2.00000","processed_quantity":"2.00000","price":"0.50000000","processed_premium":"100.00000","regulatory_fees":"0.04","contract_fees":"1.30","created_at":"2018-06-18T14:00:00.000000Z","updated_at":"2018-06-18T14:00:02.000000Z","legs":[`+
+		`{"option":"https://api.robinhood.com/options/instruments/short-put/","side":"sell","position_effect":"open","ratio_quantity":1,"executions":[{"id":"x1","timestamp":"2018-06-18T14:00:01.000000Z","quantity":"2.00000","price":"1.20000000","settlement_date":"2018-06-19"}]},`+
+		`{"option":"https://api.robinhood.com/options/instruments/long-put/","side":"buy","position_effect":"open","ratio_quantity":1,"executions":[{"id":"x2","timestamp":"2018-06-18T14:00:01.000000Z","quantity":"2.00000","price":"0.70000000","settlement_date":"2018-06-19"}]}`+
+		`]}],"next":null}`))
+	httpmock.RegisterResponder("GET", apiURL+optionsURI+"short-put/", httpmock.NewStringResponder(200,
+		`{"id":"short-put","strike_price":"270.0000","expiration_date":"2018-07-20","type":"put","chain_symbol":"SPY"}`))
+	httpmock.RegisterResponder("GET", apiURL+optionsURI+"long-put/", httpmock.NewStringResponder(200,
+		`{"id":"long-put","strike_price":"265.0000","expiration_date":"2018-07-20","type":"put","chain_symbol":"SPY"}`))
+
+	c := Client{Token: "token"}
+	orders, err := c.OptionOrders()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(orders) != 1 {
+		t.Fatalf("len(orders) = %d, want 1", len(orders))
+	}
+	o := orders[0]
+	if o.Symbol != "SPY" || o.Account != "https://api.robinhood.com/accounts/5RY82436/" || o.Debit || o.Premium != 0.5 || o.FilledQuantity != 2 || math.Abs(o.Fees-1.34) > 1e-9 || len(o.Legs) != 2 {
+		t.Fatalf("order = %+v", o)
+	}
+	short, long := o.Legs[0], o.Legs[1]
+	if short.Side != SellToOpen || short.Chain.Strike != 270 || short.Chain.Type != Put || short.RatioQuantity != 1 {
+		t.Errorf("short leg = %+v", short)
+	}
+	if long.Side != BuyToOpen || long.Chain.Strike != 265 || len(long.Executions) != 1 || long.Executions[0].Price != 0.7 {
+		t.Errorf("long leg = %+v", long)
+	}
+}
+
+func TestOptionOrdersUnresolved(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	httpmock.RegisterResponder("GET", apiURL+optionOrdersURI, httpmock.NewStringResponder(200, `{"previous":null,"results":[`+
+		`{"id":"oo2","chain_symbol":"SPY","state":"filled","type":"market","time_in_force":"gfd","direction":"debit","quantity":"1.00000","processed_quantity":"1.00000","price":null,"processed_premium":"50.00000","created_at":"2018-06-19T14:00:00.000000Z","updated_at":"2018-06-19T14:00:02.000000Z","legs":[`+
+		`{"option":"https://api.robinhood.com/options/instruments/gone/","side":"buy","position_effect":"open","ratio_quantity":1,"executions":[]}]},`+
+		`{"id":"oo1","chain_symbol":"SPY","state":"filled","type":"limit","time_in_force":"gfd","direction":"credit","quantity":"1.00000","processed_quantity":"1.00000","price":"1.20000000","processed_premium":"120.00000","created_at":"2018-06-18T14:00:00.000000Z","updated_at":"2018-06-18T14:00:02.000000Z","legs":[`+
+		`{"option":"https://api.robinhood.com/options/instruments/short-put/","side":"sell","position_effect":"open","ratio_quantity":1,"executions":[]}]}`+
+		`],"next":null}`))
+	httpmock.RegisterResponder("GET", apiURL+optionsURI+"short-put/", httpmock.NewStringResponder(200,
+		`{"id":"short-put","strike_price":"270.0000","expiration_date":"2018-07-20","type":"put","chain_symbol":"SPY"}`))
+	httpmock.RegisterResponder("GET", apiURL+optionsURI+"gone/", httpmock.NewStringResponder(404, `{"detail":"Not found."}`))
+
+	c := Client{Token: "token"}
+	orders, err := c.OptionOrders()
+	unresolved, ok := err.(*UnresolvedInstrumentsError)
+	if !ok || len(unresolved.Instruments) != 1 || unresolved.Instruments[0] != "https://api.robinhood.com/options/instruments/gone/" {
+		t.Fatalf("err = %v, want the gone option unresolved", err)
+	}
+	if len(orders) != 2 {
+		t.Fatalf("len(orders) = %d, want 2", len(orders))
+	}
+	if gone := orders[0].Legs[0]; gone.Chain != (Chain{}) || gone.Option != "https://api.robinhood.com/options/instruments/gone/" {
+		t.Errorf("unresolved leg = %+v", gone)
+	}
+	if put := orders[1].Legs[0]; put.Chain.Strike != 270 {
+		t.Errorf("resolved leg = %+v", put)
+	}
+}
@@ -0,0 +1,149 @@
+// Package performance measures the performance of an account from the history
+// of its value and its deposits and withdrawals: time-weighted return, maximum
+// drawdown, volatility and Sharpe ratio.
+package performance
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	rh "github.com/edpin/robinhood"
+)
+
+// Point is the value of an account at a point in time.
+type Point struct {
+	Time  time.Time
+	Value float64
+}
+
+// Flow is money moved into the account, or out of it if Amount is negative.
+type Flow struct {
+	Time   time.Time
+	Amount float64
+}
+
+// Series is the value of an account over time and the flows in and out of it
+// over the same period.
+type Series struct {
+	Points []Point // Oldest first.
+	Flows  []Flow
+}
+
+// FromHistory returns the series of closing equities of h. Flows must be
+// added to the series separately.
+func FromHistory(h rh.EquityHistory) Series {
+	var s Series
+	for _, p := range h.Points {
+		s.Points = append(s.Points, Point{Time: p.Time, Value: p.CloseEquity})
+	}
+	return s
+}
+
+// PeriodsPerYear returns the number of intervals in a year of trading, to
+// annualize statistics of returns.
+func PeriodsPerYear(i rh.Interval) float64 {
+	const tradingDays = 252
+	switch i {
+	case rh.Interval5Minutes:
+		return tradingDays * 78
+	case rh.Interval10Minutes:
+		return tradingDays * 39
+	case rh.IntervalHour:
+		return tradingDays * 6.5
+	case rh.IntervalWeek:
+		return 52
+	default:
+		return tradingDays
+	}
+}
+
+// Returns returns the return of each period between consecutive points, net
+// of flows. Flows after a point and up to the next one are assumed to happen
+// at the start of that period. Flows before the first point are ignored.
+func (s Series) Returns() []float64 {
+	flows := make([]Flow, len(s.Flows))
+	copy(flows, s.Flows)
+	sort.Slice(flows, func(i, j int) bool { return flows[i].Time.Before(flows[j].Time) })
+
+	var returns []float64
+	f := 0
+	for f < len(flows) && len(s.Points) > 0 && !flows[f].Time.After(s.Points[0].Time) {
+		f++
+	}
+	for i := 1; i < len(s.Points); i++ {
+		start := s.Points[i-1].Value
+		for f < len(flows) && !flows[f].Time.After(s.Points[i].Time) {
+			start += flows[f].Amount
+			f++
+		}
+		if start == 0 {
+			returns = append(returns, 0)
+			continue
+		}
+		returns = append(returns, s.Points[i].Value/start-1)
+	}
+	return returns
+}
+
+// TimeWeightedReturn returns the return of the series, compounding the return
+// of each period so that flows don't affect it.
+func (s Series) TimeWeightedReturn() float64 {
+	growth := 1.0
+	for _, r := range s.Returns() {
+		growth *= 1 + r
+	}
+	return growth - 1
+}
+
+// MaxDrawdown returns the largest fall, as a positive fraction, from a peak of
+// the series to a later trough. It is measured on returns, so flows don't
+// count as gains or losses.
+func (s Series) MaxDrawdown() float64 {
+	growth, peak, max := 1.0, 1.0, 0.0
+	for _, r := range s.Returns() {
+		growth *= 1 + r
+		peak = math.Max(peak, growth)
+		max = math.Max(max, 1-growth/peak)
+	}
+	return max
+}
+
+// Volatility returns the annualized standard deviation of the returns of the
+// series, given the number of its periods in a year.
+func (s Series) Volatility(periodsPerYear float64) float64 {
+	_, sd := meanStdDev(s.Returns())
+	return sd * math.Sqrt(periodsPerYear)
+}
+
+// Sharpe returns the annualized Sharpe ratio of the series: its mean return
+// in excess of the annual risk-free rate, over the standard deviation of its
+// returns. It is zero if returns don't vary.
+func (s Series) Sharpe(riskFree, periodsPerYear float64) float64 {
+	mean, sd := meanStdDev(s.Returns())
+	if sd == 0 {
+		return 0
+	}
+	return (mean - riskFree/periodsPerYear) / sd * math.Sqrt(periodsPerYear)
+}
+
+// meanStdDev returns the mean and sample standard deviation of xs. The
+// standard deviation is zero if there are fewer than two values.
+func meanStdDev(xs []float64) (float64, float64) {
+	if len(xs) == 0 {
+		return 0, 0
+	}
+	var sum float64
+	for _, x := range xs {
+		sum += x
+	}
+	mean := sum / float64(len(xs))
+	if len(xs) < 2 {
+		return mean, 0
+	}
+	var ss float64
+	for _, x := range xs {
+		ss += (x - mean) * (x - mean)
+	}
+	return mean, math.Sqrt(ss / float64(len(xs)-1))
+}
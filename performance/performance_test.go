@@ -0,0 +1,79 @@
+package performance
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	rh "github.com/edpin/robinhood"
+)
+
+func day(d int) time.Time {
+	return time.Date(2018, 6, d, 0, 0, 0, 0, time.UTC)
+}
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func TestSeries(t *testing.T) {
+	s := Series{Points: []Point{{day(1), 100}, {day(2), 110}, {day(3), 99}, {day(4), 108.9}}}
+	if got := s.Returns(); len(got) != 3 || !near(got[0], 0.1) || !near(got[1], -0.1) || !near(got[2], 0.1) {
+		t.Errorf("Returns = %v", got)
+	}
+	if got := s.TimeWeightedReturn(); !near(got, 0.089) {
+		t.Errorf("TimeWeightedReturn = %v, want 0.089", got)
+	}
+	if got := s.MaxDrawdown(); !near(got, 0.1) {
+		t.Errorf("MaxDrawdown = %v, want 0.1", got)
+	}
+	sd := math.Sqrt((2*(0.2/3)*(0.2/3) + (0.4/3)*(0.4/3)) / 2)
+	if got := s.Volatility(252); !near(got, sd*math.Sqrt(252)) {
+		t.Errorf("Volatility = %v, want %v", got, sd*math.Sqrt(252))
+	}
+	if got, want := s.Sharpe(0.0252, 252), (0.1/3-0.0001)/sd*math.Sqrt(252); !near(got, want) {
+		t.Errorf("Sharpe = %v, want %v", got, want)
+	}
+}
+
+func TestFlows(t *testing.T) {
+	// A deposit of 50 isn't a gain, and a withdrawal of 100 isn't a loss.
+	s := Series{
+		Points: []Point{{day(1), 100}, {day(2), 165}, {day(3), 65}},
+		Flows: []Flow{
+			{day(3), -100},
+			{day(1), 1000}, // Before the first point; ignored.
+			{day(2).Add(-time.Hour), 50},
+		},
+	}
+	got := s.Returns()
+	if len(got) != 2 || !near(got[0], 0.1) || !near(got[1], 0) {
+		t.Errorf("Returns = %v, want [0.1 0]", got)
+	}
+	if got := s.TimeWeightedReturn(); !near(got, 0.1) {
+		t.Errorf("TimeWeightedReturn = %v, want 0.1", got)
+	}
+	if got := s.MaxDrawdown(); got != 0 {
+		t.Errorf("MaxDrawdown = %v, want 0", got)
+	}
+	if got := (Series{Points: s.Points[:1]}).Sharpe(0, 252); got != 0 {
+		t.Errorf("Sharpe of a single point = %v, want 0", got)
+	}
+}
+
+func TestFromHistory(t *testing.T) {
+	h := rh.EquityHistory{Interval: rh.IntervalDay, Points: []rh.EquityPoint{
+		{Time: day(1), CloseEquity: 100, AdjustedCloseEquity: 90},
+		{Time: day(2), CloseEquity: 105, AdjustedCloseEquity: 95},
+	}}
+	s := FromHistory(h)
+	if len(s.Points) != 2 || s.Points[1] != (Point{day(2), 105}) {
+		t.Errorf("FromHistory = %+v", s)
+	}
+	if got := PeriodsPerYear(h.Interval); got != 252 {
+		t.Errorf("PeriodsPerYear(day) = %v, want 252", got)
+	}
+	if got := PeriodsPerYear(rh.IntervalWeek); got != 52 {
+		t.Errorf("PeriodsPerYear(week) = %v, want 52", got)
+	}
+}
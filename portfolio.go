@@ -2,65 +2,174 @@ package robinhood
 
 import (
 	"encoding/json"
-	"log"
-	"net/http"
+	"fmt"
 	"net/url"
+	"time"
 )
 
 // This file deals with portfolio information for a given account.
 
 // Position identifies a position in a portfolio.
 type Position struct {
-	Symbol   string
-	Name     string
-	BuyPrice float64
-	Quantity float64
-  // TODO: add other fields.
+	Instrument Instrument
+	Symbol     string
+	Name       string
+	BuyPrice   float64 // Average price paid per share.
+	Quantity   float64
+
+	// Shares held for pending orders, and shares to be received or delivered
+	// from the exercise or assignment of options.
+	SharesHeldForBuys              float64
+	SharesHeldForSells             float64
+	SharesPendingFromOptionsEvents float64
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+
+	// Price is the current price per share. It is zero if there is no quote
+	// for Symbol, in which case the fields below are zero too.
+	Price       float64
+	MarketValue float64 // Price × Quantity.
+	// UnrealizedPL is the profit or loss of the position if sold at Price.
+	UnrealizedPL float64
+	// UnrealizedPLPercent is UnrealizedPL as a percentage of the amount paid.
+	UnrealizedPLPercent float64
+	// Weight is the fraction, between 0 and 1, of the total market value of
+	// all positions that this position accounts for.
+	Weight float64
 }
 
-// Portfolio returns a slice of Position a user has in their account.
+// Portfolio returns a slice of Position a user has in their account, with
+// current prices.
+//
+// Instruments are resolved concurrently and cached. If the instruments of some
+// positions can't be resolved, Portfolio returns all positions along with an
+// *UnresolvedPositionsError listing them; those positions have no Symbol,
+// Name or price. Otherwise, if some symbols have no quote, it returns all
+// positions along with an *UnknownSymbolsError listing them; their prices are
+// left zero.
 func (c *Client) Portfolio() ([]Position, error) {
-	var positions []Position
 	pos, err := c.portfolio()
 	if err != nil {
 		return nil, err
 	}
-	for _, p := range pos {
-		req, err := http.NewRequest("GET", p.URL, nil)
+	positions := make([]Position, len(pos))
+	for i, p := range pos {
+		positions[i], err = newPosition(p)
 		if err != nil {
-			return nil, err
+			return nil, fmt.Errorf("error parsing position in %s: %v", p.URL, err)
 		}
-		resp, err := c.doReqWithAuth(req)
-		if err != nil {
-			log.Printf("Error fetching details for position %v: %v", p, err)
-			continue
+	}
+
+	urls := make([]Instrument, len(positions))
+	for i, p := range positions {
+		urls[i] = p.Instrument
+	}
+	insts, unresolved := c.resolveInstruments(urls)
+	for i, inst := range insts {
+		positions[i].Symbol = inst.Symbol
+		positions[i].Name = inst.Name
+	}
+
+	err = c.valuePositions(positions)
+	if _, ok := err.(*UnknownSymbolsError); err != nil && !ok {
+		return nil, err
+	}
+	if unresolved != nil {
+		return positions, unresolved
+	}
+	return positions, err
+}
+
+// UnresolvedPositionsError is returned by Portfolio, along with all positions,
+// when the instruments of some positions can't be resolved.
+type UnresolvedPositionsError = UnresolvedInstrumentsError
+
+// newPosition converts p to the external format, without its symbol, name or
+// market value.
+func newPosition(p position) (Position, error) {
+	buyPrice, err := parseFloat64(p.BuyPrice, nil)
+	quantity, err := parseFloat64(p.Quantity, err)
+	heldForBuys, err := parseOptionalFloat64(p.SharesHeldForBuys, err)
+	heldForSells, err := parseOptionalFloat64(p.SharesHeldForSells, err)
+	pendingFromOptions, err := parseOptionalFloat64(p.SharesPendingFromOptionsEvents, err)
+	createdAt, err := parseTime(p.CreatedAt, err)
+	updatedAt, err := parseTime(p.UpdatedAt, err)
+	return Position{
+		Instrument:                     Instrument(p.URL),
+		BuyPrice:                       buyPrice,
+		Quantity:                       quantity,
+		SharesHeldForBuys:              heldForBuys,
+		SharesHeldForSells:             heldForSells,
+		SharesPendingFromOptionsEvents: pendingFromOptions,
+		CreatedAt:                      createdAt,
+		UpdatedAt:                      updatedAt,
+	}, err
+}
+
+// valuePositions sets the price, market value, unrealized profit and loss and
+// weight of positions from a batched quote of their symbols.
+func (c *Client) valuePositions(positions []Position) error {
+	var symbols []string
+	for _, p := range positions {
+		if p.Symbol != "" {
+			symbols = append(symbols, p.Symbol)
 		}
-		var detail detailedPosition
-		err = json.Unmarshal(resp, &detail)
-		if err != nil {
-			log.Printf("Error unmarshalling details for position %v: %v", p, err)
-			continue
+	}
+	if len(symbols) == 0 {
+		return nil
+	}
+	quotes, err := c.Quote(symbols)
+	if _, ok := err.(*UnknownSymbolsError); err != nil && !ok {
+		return err
+	}
+	prices := make(map[string]float64)
+	for _, q := range quotes {
+		prices[q.Symbol] = q.Price()
+	}
+	for i := range positions {
+		if positions[i].Symbol != "" {
+			positions[i].Price = prices[positions[i].Symbol]
 		}
-		buyPrice, err := parseFloat64(p.BuyPrice, nil)
-		quantity, err := parseFloat64(p.Quantity, err)
-		if err != nil {
-			log.Printf("Error parsing float: %v", err)
+	}
+	revaluePositions(positions)
+	return err
+}
+
+// revaluePositions sets the market value, unrealized profit and loss and weight
+// of positions from their Price.
+func revaluePositions(positions []Position) {
+	var total float64
+	for i := range positions {
+		p := &positions[i]
+		p.MarketValue, p.UnrealizedPL, p.UnrealizedPLPercent, p.Weight = 0, 0, 0, 0
+		if p.Price == 0 {
 			continue
 		}
-		positions = append(positions, Position{
-			Symbol:   detail.Symbol,
-			Name:     detail.Name,
-			BuyPrice: buyPrice,
-			Quantity: quantity,
-		})
+		p.MarketValue = p.Price * p.Quantity
+		cost := p.BuyPrice * p.Quantity
+		p.UnrealizedPL = p.MarketValue - cost
+		if cost != 0 {
+			p.UnrealizedPLPercent = p.UnrealizedPL / cost * 100
+		}
+		total += p.MarketValue
+	}
+	if total != 0 {
+		for i := range positions {
+			positions[i].Weight = positions[i].MarketValue / total
+		}
 	}
-	return positions, nil
 }
 
 type position struct {
-	BuyPrice string `json:"average_buy_price"`
-	URL      string `json:"instrument"`
-	Quantity string `json:"quantity"`
+	BuyPrice                       string `json:"average_buy_price"`
+	URL                            string `json:"instrument"`
+	Quantity                       string `json:"quantity"`
+	SharesHeldForBuys              string `json:"shares_held_for_buys"`
+	SharesHeldForSells             string `json:"shares_held_for_sells"`
+	SharesPendingFromOptionsEvents string `json:"shares_pending_from_options_events"`
+	CreatedAt                      string `json:"created_at"`
+	UpdatedAt                      string `json:"updated_at"`
 }
 
 func (c *Client) portfolio() ([]position, error) {
@@ -77,8 +186,3 @@ func (c *Client) portfolio() ([]position, error) {
 	}
 	return positions, nil
 }
-
-type detailedPosition struct {
-	Symbol string `json:"symbol"`
-	Name   string `json:"simple_name"`
-}
@@ -0,0 +1,113 @@
+package robinhood
+
+import (
+	"testing"
+	"time"
+
+	httpmock "gopkg.in/jarcoal/httpmock.v1"
+)
+
+var portfolio = map[string]string{
+	apiURL + accountsURI + "account/" + positionsURI + "?nonzero=true": `{"previous":null,"results":[` +
+		`{"shares_held_for_stock_grants":"0.0000","account":"https://api.robinhood.com/accounts/account/","pending_average_buy_price":"150.0000","shares_held_for_options_events":"0.0000","intraday_average_buy_price":"0.0000","url":"https://api.robinhood.com/positions/account/450dfc6d-5510-4d40-abfb-f633b7d9be3e/","shares_held_for_options_collateral":"0.0000","created_at":"2018-03-01T14:30:05.123456Z","updated_at":"2018-06-20T15:00:00.654321Z","shares_held_for_buys":"0.0000","average_buy_price":"150.0000","instrument":"https://api.robinhood.com/instruments/450dfc6d-5510-4d40-abfb-f633b7d9be3e/","intraday_quantity":"0.0000","shares_held_for_sells":"2.0000","shares_pending_from_options_events":"0.0000","quantity":"10.0000"},` +
+		`{"account":"https://api.robinhood.com/accounts/account/","created_at":"2018-04-01T14:30:05Z","updated_at":"2018-04-01T14:30:05Z","shares_held_for_buys":"5.0000","average_buy_price":"20.0000","instrument":"https://api.robinhood.com/instruments/1111-xyz/","shares_held_for_sells":"0.0000","shares_pending_from_options_events":"100.0000","quantity":"50.0000"}` +
+		`],"next":null}`,
+	apiURL + instrumentsURI + "450dfc6d-5510-4d40-abfb-f633b7d9be3e/": `{"id":"450dfc6d-5510-4d40-abfb-f633b7d9be3e","url":"https://api.robinhood.com/instruments/450dfc6d-5510-4d40-abfb-f633b7d9be3e/","symbol":"AAPL","simple_name":"Apple","name":"Apple Inc. - Common Stock","type":"stock","state":"active","tradeable":true}`,
+	apiURL + instrumentsURI + "1111-xyz/":                             `{"id":"1111-xyz","url":"https://api.robinhood.com/instruments/1111-xyz/","symbol":"XYZ","simple_name":"XYZ","name":"XYZ Corp","type":"stock","state":"inactive","tradeable":false}`,
+	apiURL + quotesURI + "?symbols=AAPL,XYZ":                          `{"results":[{"ask_price":"180.0100","bid_price":"179.9900","last_trade_price":"180.0000","symbol":"AAPL"},null]}`,
+}
+
+func TestPortfolio(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	for url, reply := range portfolio {
+		httpmock.RegisterResponder("GET", url, httpmock.NewStringResponder(200, reply))
+	}
+
+	c := Client{
+		AccountID: "account",
+		Token:     "token",
+	}
+	positions, err := c.Portfolio()
+	unknown, ok := err.(*UnknownSymbolsError)
+	if !ok || len(unknown.Symbols) != 1 || unknown.Symbols[0] != "XYZ" {
+		t.Fatalf("err = %v, want XYZ unknown", err)
+	}
+	if len(positions) != 2 {
+		t.Fatalf("len(positions) = %d, want 2", len(positions))
+	}
+
+	want := Position{
+		Instrument:          "https://api.robinhood.com/instruments/450dfc6d-5510-4d40-abfb-f633b7d9be3e/",
+		Symbol:              "AAPL",
+		Name:                "Apple",
+		BuyPrice:            150,
+		Quantity:            10,
+		SharesHeldForSells:  2,
+		CreatedAt:           time.Date(2018, 3, 1, 14, 30, 5, 123456000, time.UTC),
+		UpdatedAt:           time.Date(2018, 6, 20, 15, 0, 0, 654321000, time.UTC),
+		Price:               180,
+		MarketValue:         1800,
+		UnrealizedPL:        300,
+		UnrealizedPLPercent: 20,
+		Weight:              1,
+	}
+	got := positions[0]
+	if !got.CreatedAt.Equal(want.CreatedAt) || !got.UpdatedAt.Equal(want.UpdatedAt) {
+		t.Errorf("timestamps = %v, %v; want %v, %v", got.CreatedAt, got.UpdatedAt, want.CreatedAt, want.UpdatedAt)
+	}
+	got.CreatedAt, got.UpdatedAt = want.CreatedAt, want.UpdatedAt
+	if got != want {
+		t.Errorf("AAPL = %+v, want %+v", got, want)
+	}
+
+	xyz := positions[1]
+	if xyz.Symbol != "XYZ" || xyz.SharesHeldForBuys != 5 || xyz.SharesPendingFromOptionsEvents != 100 || xyz.Price != 0 || xyz.MarketValue != 0 || xyz.Weight != 0 {
+		t.Errorf("XYZ = %+v", xyz)
+	}
+}
+
+func TestPortfolioUnresolved(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	for url, reply := range portfolio {
+		httpmock.RegisterResponder("GET", url, httpmock.NewStringResponder(200, reply))
+	}
+	httpmock.RegisterResponder("GET", apiURL+accountsURI+"account/"+positionsURI+"?nonzero=true", httpmock.NewStringResponder(200, `{"previous":null,"results":[`+
+		`{"created_at":"2018-03-01T14:30:05Z","updated_at":"2018-03-01T14:30:05Z","average_buy_price":"150.0000","instrument":"https://api.robinhood.com/instruments/450dfc6d-5510-4d40-abfb-f633b7d9be3e/","quantity":"10.0000"},`+
+		`{"created_at":"2018-03-01T14:30:05Z","updated_at":"2018-03-01T14:30:05Z","average_buy_price":"1.0000","instrument":"https://api.robinhood.com/instruments/gone/","quantity":"3.0000"}`+
+		`],"next":null}`))
+	httpmock.RegisterResponder("GET", apiURL+instrumentsURI+"gone/", httpmock.NewStringResponder(404, `{"detail":"Not found."}`))
+	httpmock.RegisterResponder("GET", apiURL+quotesURI+"?symbols=AAPL", httpmock.NewStringResponder(200,
+		`{"results":[{"ask_price":"180.0100","bid_price":"179.9900","last_trade_price":"180.0000","symbol":"AAPL"}]}`))
+
+	c := Client{
+		AccountID: "account",
+		Token:     "token",
+	}
+	positions, err := c.Portfolio()
+	unresolved, ok := err.(*UnresolvedPositionsError)
+	if !ok || len(unresolved.Instruments) != 1 || unresolved.Instruments[0] != "https://api.robinhood.com/instruments/gone/" {
+		t.Fatalf("err = %v, want the gone instrument unresolved", err)
+	}
+	if len(positions) != 2 {
+		t.Fatalf("len(positions) = %d, want 2", len(positions))
+	}
+	if p := positions[0]; p.Symbol != "AAPL" || p.MarketValue != 1800 || p.Weight != 1 {
+		t.Errorf("AAPL = %+v", p)
+	}
+	if p := positions[1]; p.Symbol != "" || p.Quantity != 3 || p.Price != 0 || p.Weight != 0 {
+		t.Errorf("unresolved = %+v", p)
+	}
+
+	// Resolved instruments are cached.
+	before := httpmock.GetTotalCallCount()
+	if _, err := c.Portfolio(); err == nil {
+		t.Fatal("expected unresolved positions error")
+	}
+	if got := httpmock.GetTotalCallCount() - before; got != 3 {
+		t.Errorf("second call made %d requests, want 3", got)
+	}
+}
@@ -0,0 +1,94 @@
+package pricing
+
+// This file implements the Bjerksund-Stensland (1993) approximation for
+// American options.
+
+import (
+	"math"
+
+	rh "github.com/edpin/robinhood"
+)
+
+// american prices an American option. Greeks are computed by finite
+// differences, as the approximation has no closed form for them.
+func american(p Params) Result {
+	if p.Years <= 0 || p.Vol <= 0 {
+		return expired(p)
+	}
+	r := Result{Price: americanPrice(p)}
+
+	h := p.Spot * 1e-3
+	up, down := p, p
+	up.Spot += h
+	down.Spot -= h
+	pu, pd := americanPrice(up), americanPrice(down)
+	r.Greeks.Delta = (pu - pd) / (2 * h)
+	r.Greeks.Gamma = (pu - 2*r.Price + pd) / (h * h)
+
+	later := p
+	later.Years -= 1.0 / 365
+	if later.Years > 0 {
+		r.Greeks.Theta = americanPrice(later) - r.Price
+	} else {
+		r.Greeks.Theta = intrinsic(p) - r.Price
+	}
+
+	up, down = p, p
+	up.Vol += 0.005
+	down.Vol -= 0.005
+	r.Greeks.Vega = americanPrice(up) - americanPrice(down)
+
+	up, down = p, p
+	up.Rate += 0.005
+	down.Rate -= 0.005
+	r.Greeks.Rho = americanPrice(up) - americanPrice(down)
+	return r
+}
+
+// americanPrice returns the price of an American option, which is never less
+// than that of the European one or than its intrinsic value.
+func americanPrice(p Params) float64 {
+	carry := p.Rate - p.Dividend
+	var price float64
+	if p.Type == rh.Put {
+		// Put-call transformation: P(S, K, r, b) = C(K, S, r-b, -b).
+		price = bsCall(p.Strike, p.Spot, p.Years, p.Rate-carry, -carry, p.Vol)
+	} else {
+		price = bsCall(p.Spot, p.Strike, p.Years, p.Rate, carry, p.Vol)
+	}
+	return math.Max(math.Max(price, european(p).Price), intrinsic(p))
+}
+
+// bsCall is the Bjerksund-Stensland (1993) price of an American call with cost
+// of carry b.
+func bsCall(s, k, t, r, b, v float64) float64 {
+	if b >= r {
+		// Never optimal to exercise early.
+		return european(Params{Type: rh.Call, Spot: s, Strike: k, Years: t, Rate: r, Dividend: r - b, Vol: v}).Price
+	}
+	v2 := v * v
+	beta := (0.5 - b/v2) + math.Sqrt(math.Pow(b/v2-0.5, 2)+2*r/v2)
+	bInf := beta / (beta - 1) * k
+	b0 := math.Max(k, r/(r-b)*k)
+	ht := -(b*t + 2*v*math.Sqrt(t)) * b0 / (bInf - b0)
+	i := b0 + (bInf-b0)*(1-math.Exp(ht))
+	if s >= i {
+		return s - k
+	}
+	alpha := (i - k) * math.Pow(i, -beta)
+	return alpha*math.Pow(s, beta) -
+		alpha*phi(s, t, beta, i, i, r, b, v) +
+		phi(s, t, 1, i, i, r, b, v) -
+		phi(s, t, 1, k, i, r, b, v) -
+		k*phi(s, t, 0, i, i, r, b, v) +
+		k*phi(s, t, 0, k, i, r, b, v)
+}
+
+func phi(s, t, gamma, h, i, r, b, v float64) float64 {
+	v2 := v * v
+	lambda := (-r + gamma*b + 0.5*gamma*(gamma-1)*v2) * t
+	d := -(math.Log(s/h) + (b+(gamma-0.5)*v2)*t) / (v * math.Sqrt(t))
+	kappa := 2*b/v2 + (2*gamma - 1)
+	return math.Exp(lambda) * math.Pow(s, gamma) *
+		(normCDF(d) - math.Pow(i/s, kappa)*normCDF(d-2*math.Log(i/s)/(v*math.Sqrt(t))))
+}
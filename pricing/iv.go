@@ -0,0 +1,59 @@
+package pricing
+
+// This file solves for implied volatility.
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	minVol       = 1e-4
+	maxVol       = 5.0 // 500%.
+	volTolerance = 1e-6
+	maxIter      = 100
+)
+
+// ImpliedVol returns the volatility at which the model prices the option at
+// price. p.Vol is ignored. It fails if price is outside the range of prices
+// the model can produce, e.g. below intrinsic value.
+func ImpliedVol(m Model, price float64, p Params) (float64, error) {
+	if p.Years <= 0 {
+		return 0, fmt.Errorf("option has expired")
+	}
+	volPrice := func(v float64) float64 {
+		q := p
+		q.Vol = v
+		return Price(m, q).Price
+	}
+	lo, hi := minVol, maxVol
+	if price < volPrice(lo)-volTolerance || price > volPrice(hi)+volTolerance {
+		return 0, fmt.Errorf("price %v outside of the range of %v prices", price, m)
+	}
+	// Newton's method, falling back to bisection whenever a step leaves the
+	// bracket [lo, hi] known to contain the solution.
+	v := 0.3
+	for i := 0; i < maxIter; i++ {
+		q := p
+		q.Vol = v
+		r := Price(m, q)
+		diff := r.Price - price
+		if math.Abs(diff) < volTolerance*math.Max(1, price) {
+			return v, nil
+		}
+		if diff > 0 {
+			hi = v
+		} else {
+			lo = v
+		}
+		next := v - diff/(r.Greeks.Vega*100)
+		if r.Greeks.Vega <= 0 || math.IsNaN(next) || next <= lo || next >= hi {
+			next = (lo + hi) / 2
+		}
+		if hi-lo < volTolerance {
+			return next, nil
+		}
+		v = next
+	}
+	return v, fmt.Errorf("implied volatility did not converge for price %v", price)
+}
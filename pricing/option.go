@@ -0,0 +1,78 @@
+package pricing
+
+// This file prices rh.Option values.
+
+import (
+	"time"
+
+	rh "github.com/edpin/robinhood"
+)
+
+// Market holds the inputs to price an rh.Option that the option itself doesn't
+// carry.
+type Market struct {
+	Spot     float64 // Price of the underlying, e.g. from rh.Quote.Price.
+	Rate     float64 // Risk-free interest rate, continuously compounded.
+	Dividend float64 // Dividend yield of the underlying, continuously compounded.
+
+	// Now is the time of pricing. If zero, the current time is used.
+	Now time.Time
+}
+
+// YearsToExpiration returns the time from now until the close of the market on
+// the expiration date, in years.
+func YearsToExpiration(now, expiration time.Time) float64 {
+	// Options stop trading at 4pm New York time, which is 20:00 UTC during
+	// daylight saving time. An hour of difference in winter is immaterial.
+	expires := time.Date(expiration.Year(), expiration.Month(), expiration.Day(), 20, 0, 0, 0, time.UTC)
+	return expires.Sub(now).Hours() / 24 / 365
+}
+
+// Params returns the parameters to price o, using its implied volatility.
+func (m Market) Params(o rh.Option) Params {
+	now := m.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	return Params{
+		Type:     o.Type,
+		Spot:     m.Spot,
+		Strike:   o.Strike,
+		Years:    YearsToExpiration(now, o.Expiration),
+		Rate:     m.Rate,
+		Dividend: m.Dividend,
+		Vol:      o.IV,
+	}
+}
+
+// PriceOption prices o with the model at its implied volatility, o.IV.
+func PriceOption(model Model, o rh.Option, m Market) Result {
+	return Price(model, m.Params(o))
+}
+
+// OptionVols are the implied volatilities of an option's quote.
+type OptionVols struct {
+	Bid  float64 // Zero if there is no bid.
+	Ask  float64
+	Mark float64 // Of rh.Option.MarketPrice.
+}
+
+// ImpliedVols solves for the implied volatilities of the bid, ask and market
+// price of o.
+func ImpliedVols(model Model, o rh.Option, m Market) (OptionVols, error) {
+	p := m.Params(o)
+	var vols OptionVols
+	var err error
+	if o.Bid > 0 {
+		vols.Bid, err = ImpliedVol(model, o.Bid, p)
+		if err != nil {
+			return vols, err
+		}
+	}
+	vols.Ask, err = ImpliedVol(model, o.Ask, p)
+	if err != nil {
+		return vols, err
+	}
+	vols.Mark, err = ImpliedVol(model, o.MarketPrice, p)
+	return vols, err
+}
@@ -0,0 +1,122 @@
+// Package pricing computes theoretical option prices, greeks and implied
+// volatilities, to sanity-check the quotes returned by the robinhood package.
+package pricing
+
+import (
+	"math"
+
+	rh "github.com/edpin/robinhood"
+)
+
+// Model is an option pricing model.
+type Model int
+
+// See description for Model.
+const (
+	// BlackScholes prices European options, which can only be exercised at
+	// expiration.
+	BlackScholes Model = iota
+
+	// BjerksundStensland prices American options, which can be exercised at
+	// any time, using the Bjerksund-Stensland (1993) approximation.
+	BjerksundStensland
+)
+
+// String implements Stringer.
+func (m Model) String() string {
+	switch m {
+	case BlackScholes:
+		return "Black-Scholes"
+	case BjerksundStensland:
+		return "Bjerksund-Stensland"
+	default:
+		return "invalid model"
+	}
+}
+
+// Params are the inputs of a pricing model.
+type Params struct {
+	Type     rh.OptionType
+	Spot     float64 // Price of the underlying.
+	Strike   float64
+	Years    float64 // Time to expiration, in years.
+	Rate     float64 // Risk-free interest rate, continuously compounded.
+	Dividend float64 // Dividend yield, continuously compounded.
+	Vol      float64 // Volatility, annualized.
+}
+
+// Result is a theoretical price and its greeks. Greeks follow the conventions
+// of rh.Greeks: Theta is per calendar day, and Vega and Rho are per percentage
+// point.
+type Result struct {
+	Price  float64
+	Greeks rh.Greeks
+}
+
+// Price prices an option with the given model.
+func Price(m Model, p Params) Result {
+	if m == BjerksundStensland {
+		return american(p)
+	}
+	return european(p)
+}
+
+// european prices an option with the Black-Scholes-Merton formula.
+func european(p Params) Result {
+	if p.Years <= 0 || p.Vol <= 0 {
+		return expired(p)
+	}
+	sqrtT := math.Sqrt(p.Years)
+	d1 := (math.Log(p.Spot/p.Strike) + (p.Rate-p.Dividend+p.Vol*p.Vol/2)*p.Years) / (p.Vol * sqrtT)
+	d2 := d1 - p.Vol*sqrtT
+	dfDiv := math.Exp(-p.Dividend * p.Years)
+	dfRate := math.Exp(-p.Rate * p.Years)
+
+	var r Result
+	// Gamma and vega are the same for calls and puts.
+	r.Greeks.Gamma = dfDiv * normPDF(d1) / (p.Spot * p.Vol * sqrtT)
+	r.Greeks.Vega = p.Spot * dfDiv * normPDF(d1) * sqrtT / 100
+	decay := -p.Spot * dfDiv * normPDF(d1) * p.Vol / (2 * sqrtT)
+	if p.Type == rh.Put {
+		r.Price = p.Strike*dfRate*normCDF(-d2) - p.Spot*dfDiv*normCDF(-d1)
+		r.Greeks.Delta = -dfDiv * normCDF(-d1)
+		r.Greeks.Theta = (decay + p.Rate*p.Strike*dfRate*normCDF(-d2) - p.Dividend*p.Spot*dfDiv*normCDF(-d1)) / 365
+		r.Greeks.Rho = -p.Strike * p.Years * dfRate * normCDF(-d2) / 100
+		return r
+	}
+	r.Price = p.Spot*dfDiv*normCDF(d1) - p.Strike*dfRate*normCDF(d2)
+	r.Greeks.Delta = dfDiv * normCDF(d1)
+	r.Greeks.Theta = (decay - p.Rate*p.Strike*dfRate*normCDF(d2) + p.Dividend*p.Spot*dfDiv*normCDF(d1)) / 365
+	r.Greeks.Rho = p.Strike * p.Years * dfRate * normCDF(d2) / 100
+	return r
+}
+
+// expired prices an option with no time or volatility left at its intrinsic
+// value.
+func expired(p Params) Result {
+	var r Result
+	r.Price = intrinsic(p)
+	if r.Price > 0 {
+		r.Greeks.Delta = 1
+		if p.Type == rh.Put {
+			r.Greeks.Delta = -1
+		}
+	}
+	return r
+}
+
+// intrinsic returns the value of exercising the option now.
+func intrinsic(p Params) float64 {
+	if p.Type == rh.Put {
+		return math.Max(p.Strike-p.Spot, 0)
+	}
+	return math.Max(p.Spot-p.Strike, 0)
+}
+
+func normCDF(x float64) float64 {
+	return 0.5 * math.Erfc(-x/math.Sqrt2)
+}
+
+func normPDF(x float64) float64 {
+	return math.Exp(-x*x/2) / math.Sqrt(2*math.Pi)
+}
@@ -0,0 +1,147 @@
+package pricing
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	rh "github.com/edpin/robinhood"
+)
+
+func near(a, b, tol float64) bool {
+	return math.Abs(a-b) <= tol
+}
+
+func TestBlackScholes(t *testing.T) {
+	p := Params{Type: rh.Call, Spot: 100, Strike: 100, Years: 1, Rate: 0.05, Vol: 0.2}
+	call := Price(BlackScholes, p)
+	want := Result{Price: 10.4506, Greeks: rh.Greeks{Delta: 0.6368, Gamma: 0.01876, Theta: -0.01757, Vega: 0.3752, Rho: 0.5323}}
+	if !near(call.Price, want.Price, 1e-4) || !near(call.Greeks.Delta, want.Greeks.Delta, 1e-4) || !near(call.Greeks.Gamma, want.Greeks.Gamma, 1e-5) ||
+		!near(call.Greeks.Theta, want.Greeks.Theta, 1e-5) || !near(call.Greeks.Vega, want.Greeks.Vega, 1e-4) || !near(call.Greeks.Rho, want.Greeks.Rho, 1e-4) {
+		t.Errorf("call = %+v, want %+v", call, want)
+	}
+	p.Type = rh.Put
+	put := Price(BlackScholes, p)
+	if !near(put.Price, 5.5735, 1e-4) || !near(put.Greeks.Delta, -0.3632, 1e-4) || !near(put.Greeks.Gamma, call.Greeks.Gamma, 1e-12) {
+		t.Errorf("put = %+v", put)
+	}
+	// Put-call parity.
+	if !near(call.Price-put.Price, 100-100*math.Exp(-0.05), 1e-9) {
+		t.Errorf("put-call parity violated: %v - %v", call.Price, put.Price)
+	}
+
+	expired := Price(BlackScholes, Params{Type: rh.Put, Spot: 90, Strike: 100, Vol: 0.2})
+	if expired.Price != 10 || expired.Greeks.Delta != -1 {
+		t.Errorf("expired put = %+v, want intrinsic value", expired)
+	}
+}
+
+// binomial prices an American option on a Cox-Ross-Rubinstein tree.
+func binomial(p Params, steps int) float64 {
+	dt := p.Years / float64(steps)
+	u := math.Exp(p.Vol * math.Sqrt(dt))
+	d := 1 / u
+	q := (math.Exp((p.Rate-p.Dividend)*dt) - d) / (u - d)
+	disc := math.Exp(-p.Rate * dt)
+	values := make([]float64, steps+1)
+	for i := range values {
+		s := p
+		s.Spot = p.Spot * math.Pow(u, float64(steps-i)) * math.Pow(d, float64(i))
+		values[i] = intrinsic(s)
+	}
+	for n := steps - 1; n >= 0; n-- {
+		for i := 0; i <= n; i++ {
+			s := p
+			s.Spot = p.Spot * math.Pow(u, float64(n-i)) * math.Pow(d, float64(i))
+			values[i] = math.Max(disc*(q*values[i]+(1-q)*values[i+1]), intrinsic(s))
+		}
+	}
+	return values[0]
+}
+
+func TestBjerksundStensland(t *testing.T) {
+	// Example from Haug, The Complete Guide to Option Pricing Formulas.
+	p := Params{Type: rh.Call, Spot: 42, Strike: 40, Years: 0.75, Rate: 0.04, Dividend: 0.08, Vol: 0.35}
+	if got := Price(BjerksundStensland, p).Price; !near(got, 5.2704, 1e-4) {
+		t.Errorf("call = %v, want 5.2704", got)
+	}
+
+	tests := []Params{
+		{Type: rh.Put, Spot: 90, Strike: 100, Years: 0.5, Rate: 0.08, Vol: 0.25},
+		{Type: rh.Put, Spot: 110, Strike: 100, Years: 1, Rate: 0.05, Dividend: 0.01, Vol: 0.3},
+		{Type: rh.Call, Spot: 100, Strike: 95, Years: 0.25, Rate: 0.02, Dividend: 0.06, Vol: 0.2},
+		{Type: rh.Call, Spot: 100, Strike: 100, Years: 1, Rate: 0.05, Vol: 0.2}, // No early exercise.
+	}
+	for _, p := range tests {
+		am := Price(BjerksundStensland, p)
+		eu := Price(BlackScholes, p)
+		tree := binomial(p, 500)
+		if am.Price < eu.Price-1e-12 || am.Price < intrinsic(p) {
+			t.Errorf("%+v: American %v below European %v or intrinsic value", p, am.Price, eu.Price)
+		}
+		// The approximation slightly underprices options that are likely
+		// to be exercised early.
+		if !near(am.Price, tree, 0.02*tree) {
+			t.Errorf("%+v: American %v, binomial %v", p, am.Price, tree)
+		}
+		// Finite difference greeks have the same signs as the European ones.
+		if am.Greeks.Vega <= 0 || am.Greeks.Gamma <= 0 || math.Signbit(am.Greeks.Delta) != math.Signbit(eu.Greeks.Delta) || am.Greeks.Theta > 0 {
+			t.Errorf("%+v: American greeks %+v, European %+v", p, am.Greeks, eu.Greeks)
+		}
+	}
+}
+
+func TestImpliedVol(t *testing.T) {
+	for _, m := range []Model{BlackScholes, BjerksundStensland} {
+		for _, vol := range []float64{0.05, 0.2, 0.8, 2} {
+			for _, typ := range []rh.OptionType{rh.Call, rh.Put} {
+				// Out of the money, so that early exercise doesn't make the
+				// price insensitive to low volatilities.
+				p := Params{Type: typ, Spot: 100, Strike: 105, Years: 0.3, Rate: 0.03, Dividend: 0.01, Vol: vol}
+				if typ == rh.Put {
+					p.Strike = 95
+				}
+				price := Price(m, p).Price
+				p.Vol = 0
+				got, err := ImpliedVol(m, price, p)
+				if err != nil {
+					t.Fatalf("%v %v vol %v: %v", m, typ, vol, err)
+				}
+				if !near(got, vol, 1e-4) {
+					t.Errorf("%v %v: ImpliedVol = %v, want %v", m, typ, got, vol)
+				}
+			}
+		}
+	}
+	p := Params{Type: rh.Put, Spot: 100, Strike: 105, Years: 0.3, Rate: 0.03}
+	if _, err := ImpliedVol(BjerksundStensland, 4, p); err == nil {
+		t.Error("expected error for a price below intrinsic value")
+	}
+}
+
+func TestOption(t *testing.T) {
+	now := time.Date(2018, 6, 22, 20, 0, 0, 0, time.UTC)
+	exp := time.Date(2018, 12, 21, 0, 0, 0, 0, time.UTC)
+	m := Market{Spot: 274.5, Rate: 0.02, Dividend: 0.018, Now: now}
+	p := Params{Type: rh.Call, Spot: 274.5, Strike: 280, Years: YearsToExpiration(now, exp), Rate: 0.02, Dividend: 0.018, Vol: 0.15}
+	if !near(p.Years, 182.0/365, 1e-9) {
+		t.Errorf("Years = %v, want %v", p.Years, 182.0/365)
+	}
+	bid := Price(BlackScholes, p).Price
+	p.Vol = 0.16
+	mark := Price(BlackScholes, p).Price
+	p.Vol = 0.17
+	ask := Price(BlackScholes, p).Price
+	o := rh.Option{Symbol: "SPY", Strike: 280, Expiration: exp, Type: rh.Call, Bid: bid, Ask: ask, MarketPrice: mark, IV: 0.16}
+
+	if got := PriceOption(BlackScholes, o, m).Price; !near(got, mark, 1e-9) {
+		t.Errorf("PriceOption = %v, want %v", got, mark)
+	}
+	vols, err := ImpliedVols(BlackScholes, o, m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !near(vols.Bid, 0.15, 1e-4) || !near(vols.Mark, 0.16, 1e-4) || !near(vols.Ask, 0.17, 1e-4) {
+		t.Errorf("ImpliedVols = %+v", vols)
+	}
+}
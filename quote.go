@@ -12,10 +12,24 @@ type Quote struct {
 	Symbol string
 	Ask    float64
 	Bid    float64
+	Last   float64 // Price of the last trade during regular hours.
 }
 
-// Quote returns a slice of quotes for the requested security symbols. Does
-// not work on option symbols.
+// Price returns the price of the last trade or, if there hasn't been one, the
+// midpoint between bid and ask.
+func (q Quote) Price() float64 {
+	if q.Last != 0 {
+		return q.Last
+	}
+	return (q.Bid + q.Ask) / 2
+}
+
+// Quote returns a slice of quotes for the requested security symbols, in the
+// same order as requested. Does not work on option symbols.
+//
+// Long lists of symbols are split into several concurrent requests. If some
+// symbols are unknown, Quote returns the quotes for all other symbols along
+// with an *UnknownSymbolsError listing the unknown ones.
 func (c *Client) Quote(symbol []string) ([]Quote, error) {
 	quotes, err := c.quote(symbol)
 	if err != nil {
@@ -23,9 +37,15 @@ func (c *Client) Quote(symbol []string) ([]Quote, error) {
 	}
 	// Convert prices from string to floats.
 	var qts []Quote
-	for _, q := range quotes {
+	var missing []int
+	for i, q := range quotes {
+		if q == nil {
+			missing = append(missing, i)
+			continue
+		}
 		bid, err := parseFloat64(q.Bid, nil)
 		ask, err := parseFloat64(q.Ask, err)
+		last, err := parseOptionalFloat64(q.Last, err)
 		if err != nil {
 			return nil, err
 		}
@@ -33,46 +53,49 @@ func (c *Client) Quote(symbol []string) ([]Quote, error) {
 			Symbol: q.Symbol,
 			Ask:    ask,
 			Bid:    bid,
+			Last:   last,
 		})
 	}
-	return qts, nil
+	return qts, unknownSymbols(symbol, missing)
 }
 
 type quote struct {
 	Ask        string     `json:"ask_price"`
 	Bid        string     `json:"bid_price"`
+	Last       string     `json:"last_trade_price"`
 	Symbol     string     `json:"symbol"`
 	Instrument Instrument `json:"instrument"`
 }
 
-func (c *Client) quote(symbol []string) ([]quote, error) {
-	if len(symbol) == 0 {
-		return nil, nil
-	}
-	var resp []byte
-	var err error
-	var quotes []quote
-	var q quote
-	if len(symbol) == 1 {
-		resp, err = c.get(quotesURI + symbol[0] + "/")
-	} else {
-		resp, err = c.get(quotesURI + "?symbols=" + strings.Join(symbol, ","))
-	}
-	if err != nil {
-		return nil, err
-	}
-	if len(symbol) == 1 {
-		err = json.Unmarshal(resp, &q)
-	} else {
-		var results map[string][]quote
+// quote fetches quotes in the same order as the symbols. Symbols unknown to
+// the server are nil.
+func (c *Client) quote(symbol []string) ([]*quote, error) {
+	quotes := make([]*quote, len(symbol))
+	err := forEachBatch(len(symbol), maxSymbolsPerRequest, func(start, end int) error {
+		resp, err := c.get(quotesURI + "?symbols=" + strings.Join(symbol[start:end], ","))
+		if err != nil {
+			return err
+		}
+		var results map[string][]*quote
 		err = json.Unmarshal(resp, &results)
-		quotes = results["results"]
-	}
+		if err != nil {
+			return err
+		}
+		// The server returns null for unknown symbols, but match by symbol
+		// anyway rather than rely on the order of the results.
+		bySymbol := make(map[string]*quote)
+		for _, q := range results["results"] {
+			if q != nil {
+				bySymbol[q.Symbol] = q
+			}
+		}
+		for i := start; i < end; i++ {
+			quotes[i] = bySymbol[strings.ToUpper(symbol[i])]
+		}
+		return nil
+	})
 	if err != nil {
 		return nil, err
 	}
-	if len(symbol) == 1 {
-		quotes = append(quotes, q)
-	}
 	return quotes, nil
 }
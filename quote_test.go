@@ -0,0 +1,89 @@
+package robinhood
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	httpmock "gopkg.in/jarcoal/httpmock.v1"
+)
+
+func TestQuoteBatches(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	var mu sync.Mutex
+	inFlight, maxInFlight, calls := 0, 0, 0
+	httpmock.RegisterResponder("GET", apiURL+quotesURI, func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		calls++
+		inFlight++
+		if inFlight > maxInFlight {
+			maxInFlight = inFlight
+		}
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			inFlight--
+			mu.Unlock()
+		}()
+		time.Sleep(time.Millisecond)
+
+		symbols := strings.Split(req.URL.Query().Get("symbols"), ",")
+		if len(symbols) > maxSymbolsPerRequest {
+			return httpmock.NewStringResponse(400, `{"detail":"too many symbols"}`), nil
+		}
+		var results []string
+		for _, s := range symbols {
+			if strings.HasPrefix(s, "BAD") {
+				results = append(results, "null")
+				continue
+			}
+			results = append(results, fmt.Sprintf(`{"ask_price":"%d.0100","bid_price":"%d.0000","symbol":"%s"}`, len(s), len(s), s))
+		}
+		return httpmock.NewStringResponse(200, `{"results":[`+strings.Join(results, ",")+`]}`), nil
+	})
+
+	var symbols []string
+	for i := 0; i < 500; i++ {
+		symbols = append(symbols, fmt.Sprintf("S%d", i))
+	}
+	symbols[7] = "BAD1"
+	symbols[321] = "BAD2"
+
+	c := Client{
+		Token:                 "token",
+		MaxConcurrentRequests: 2,
+	}
+	got, err := c.Quote(symbols)
+	unknown, ok := err.(*UnknownSymbolsError)
+	if !ok {
+		t.Fatalf("err = %v, want *UnknownSymbolsError", err)
+	}
+	if len(unknown.Symbols) != 2 || unknown.Symbols[0] != "BAD1" || unknown.Symbols[1] != "BAD2" {
+		t.Errorf("unknown symbols = %v, want [BAD1 BAD2]", unknown.Symbols)
+	}
+	if len(got) != len(symbols)-2 {
+		t.Fatalf("len(got) = %d, want %d", len(got), len(symbols)-2)
+	}
+	// Quotes are in the requested order.
+	j := 0
+	for _, s := range symbols {
+		if strings.HasPrefix(s, "BAD") {
+			continue
+		}
+		if got[j].Symbol != s || got[j].Bid != float64(len(s)) || got[j].Ask != float64(len(s))+0.01 {
+			t.Fatalf("got[%d] = %+v, want symbol %s", j, got[j], s)
+		}
+		j++
+	}
+	if want := (len(symbols) + maxSymbolsPerRequest - 1) / maxSymbolsPerRequest; calls != want {
+		t.Errorf("calls = %d, want %d", calls, want)
+	}
+	if maxInFlight > 2 {
+		t.Errorf("max requests in flight = %d, want <= 2", maxInFlight)
+	}
+}
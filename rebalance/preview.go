@@ -0,0 +1,40 @@
+package rebalance
+
+// This file previews rebalance plans.
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"text/tabwriter"
+)
+
+// WriteText writes the allocations of the plan before and after it, the
+// holdings it leaves alone for lack of a price, and its trades, as aligned
+// tables.
+func (p *Plan) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "SYMBOL\tPRICE\tQTY\tWEIGHT\tTARGET\tQTY AFTER\tWEIGHT AFTER")
+	for _, a := range p.Allocations {
+		target := "-"
+		if a.Targeted {
+			target = fmt.Sprintf("%.2f%%", a.Target*100)
+		}
+		fmt.Fprintf(tw, "%s\t%.2f\t%v\t%.2f%%\t%s\t%v\t%.2f%%\n",
+			a.Symbol, a.Price, a.Quantity, a.Weight*100, target, a.QuantityAfter, a.WeightAfter*100)
+	}
+	fmt.Fprintf(tw, "CASH\t\t%.2f\t\t\t%.2f\t\n", p.Cash, p.CashAfter)
+	fmt.Fprintln(tw)
+	if len(p.Unpriced) > 0 {
+		fmt.Fprintf(tw, "No price for %s, left alone.\n\n", strings.Join(p.Unpriced, ", "))
+	}
+	if len(p.Trades) == 0 {
+		fmt.Fprintln(tw, "No trades needed.")
+		return tw.Flush()
+	}
+	fmt.Fprintln(tw, "SIDE\tSYMBOL\tQTY\tPRICE\tVALUE")
+	for _, t := range p.Trades {
+		fmt.Fprintf(tw, "%s\t%s\t%v\t%.2f\t%.2f\n", t.Side, t.Symbol, t.Quantity, t.Price, t.Value())
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,333 @@
+// Package rebalance computes the trades that bring a portfolio to target
+// weights, previews them and optionally places them.
+package rebalance
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	rh "github.com/edpin/robinhood"
+)
+
+// Targets are the target weights of symbols, as fractions of the value of the
+// portfolio. They must add up to at most 1; the rest is kept in cash.
+type Targets map[string]float64
+
+// Params control which trades a rebalance makes.
+type Params struct {
+	// Reserve is the cash to keep uninvested.
+	Reserve float64
+	// MinTrade is the smallest value of a trade worth making. Smaller trades
+	// are dropped.
+	MinTrade float64
+	// Drift is how far, as an absolute weight, a position may be from its
+	// target before it is traded. E.g. 0.02 leaves a position with a target
+	// of 0.25 alone between weights of 0.23 and 0.27.
+	Drift float64
+	// Fractional allows trades of fractional shares. Otherwise quantities
+	// are rounded down to whole shares.
+	Fractional bool
+	// SellUntargeted sells positions in symbols without a target. Otherwise
+	// they are left alone.
+	SellUntargeted bool
+}
+
+// Allocation is the value of a symbol in the portfolio before and after the
+// rebalance.
+type Allocation struct {
+	Symbol   string
+	Price    float64
+	Quantity float64 // Held before the rebalance.
+	Value    float64
+	Weight   float64
+	Target   float64 // Target weight. Zero if the symbol has none.
+	Targeted bool    // Whether the symbol has a target.
+	// QuantityAfter, ValueAfter and WeightAfter are after all trades.
+	QuantityAfter float64
+	ValueAfter    float64
+	WeightAfter   float64
+}
+
+// Trade is an order to buy or sell a symbol at the market.
+type Trade struct {
+	Symbol   string
+	Side     rh.Side // Buy or Sell.
+	Quantity float64
+	Price    float64 // Quoted price the trade was planned at.
+}
+
+// Value returns the value of the trade at its planned price.
+func (t Trade) Value() float64 {
+	return t.Quantity * t.Price
+}
+
+// Plan is a rebalance of a portfolio.
+type Plan struct {
+	// Total is the value of all positions and cash, less the reserve: the
+	// value target weights are fractions of.
+	Total       float64
+	Cash        float64 // Before the rebalance.
+	CashAfter   float64
+	Allocations []Allocation // By symbol.
+	Trades      []Trade      // Sales first, then purchases.
+	// Unpriced are the symbols held without a target or a price. They are
+	// left alone and out of Total.
+	Unpriced []string
+
+	reserve float64
+}
+
+// Fetch plans a rebalance of the client's portfolio to targets, with current
+// positions and their prices from Portfolio, and prices of the other targets
+// from Quote. The cash available is the account's cash not held for orders,
+// but no more than its buying power, so that margin is never used.
+//
+// Holdings Portfolio has no quote for are left alone if they have no target;
+// see Plan.Unpriced.
+func Fetch(c *rh.Client, targets Targets, params Params) (*Plan, error) {
+	positions, err := c.Portfolio()
+	if _, ok := err.(*rh.UnknownSymbolsError); err != nil && !ok {
+		return nil, err
+	}
+	prices := make(map[string]float64)
+	for _, p := range positions {
+		if p.Price > 0 {
+			prices[p.Symbol] = p.Price
+		}
+	}
+	var list []string
+	for s := range targets {
+		if prices[s] == 0 {
+			list = append(list, s)
+		}
+	}
+	if len(list) > 0 {
+		sort.Strings(list)
+		quotes, err := c.Quote(list)
+		if _, ok := err.(*rh.UnknownSymbolsError); err != nil && !ok {
+			return nil, err
+		}
+		for _, q := range quotes {
+			prices[q.Symbol] = q.Price()
+		}
+	}
+	acc, err := c.Account()
+	if err != nil {
+		return nil, err
+	}
+	return New(positions, prices, availableCash(acc), targets, params)
+}
+
+// availableCash returns the cash of acc that can be invested without margin.
+func availableCash(acc rh.Account) float64 {
+	return math.Min(acc.Cash-acc.CashHeldForOrders, acc.BuyingPower)
+}
+
+// New plans a rebalance of positions, priced at prices by symbol, and cash to
+// targets.
+//
+// Sales come first, and their proceeds fund purchases. Purchases that cash
+// can't cover are scaled down, those furthest below target first. Shares held
+// for pending sales are never sold. Positions without a target or a price are
+// left alone and listed in Plan.Unpriced; targets without a price are an
+// error.
+func New(positions []rh.Position, prices map[string]float64, cash float64, targets Targets, params Params) (*Plan, error) {
+	sum := 0.0
+	for s, w := range targets {
+		if w < 0 {
+			return nil, fmt.Errorf("negative target weight %v for %s", w, s)
+		}
+		sum += w
+	}
+	if sum > 1+1e-9 {
+		return nil, fmt.Errorf("target weights add up to %v, more than 1", sum)
+	}
+
+	held := make(map[string]rh.Position)
+	for _, p := range positions {
+		if p.Quantity == 0 {
+			continue
+		}
+		if p.Symbol == "" {
+			return nil, fmt.Errorf("position in %s has no symbol", p.Instrument)
+		}
+		held[p.Symbol] = p
+	}
+	var symbols []string
+	for s := range targets {
+		symbols = append(symbols, s)
+	}
+	for s := range held {
+		if _, ok := targets[s]; !ok {
+			symbols = append(symbols, s)
+		}
+	}
+	sort.Strings(symbols)
+
+	p := &Plan{Cash: cash, Total: cash - params.Reserve, reserve: params.Reserve}
+	for _, s := range symbols {
+		price := prices[s]
+		target, ok := targets[s]
+		if price <= 0 && !ok {
+			p.Unpriced = append(p.Unpriced, s)
+			continue
+		}
+		if price <= 0 {
+			return nil, fmt.Errorf("no price for %s", s)
+		}
+		a := Allocation{
+			Symbol:   s,
+			Price:    price,
+			Quantity: held[s].Quantity,
+			Value:    held[s].Quantity * price,
+			Target:   target,
+			Targeted: ok,
+		}
+		p.Total += a.Value
+		p.Allocations = append(p.Allocations, a)
+	}
+	if p.Total <= 0 {
+		return nil, fmt.Errorf("nothing to rebalance: total value %.2f", p.Total)
+	}
+
+	var sells, buys []Trade
+	shortfall := make(map[string]float64)
+	for i := range p.Allocations {
+		a := &p.Allocations[i]
+		a.Weight = a.Value / p.Total
+		if !a.Targeted && !params.SellUntargeted {
+			continue
+		}
+		if math.Abs(a.Weight-a.Target) <= params.Drift {
+			continue
+		}
+		diff := a.Target*p.Total - a.Value
+		if diff < 0 {
+			sellable := a.Quantity - held[a.Symbol].SharesHeldForSells
+			q := math.Min(-diff/a.Price, sellable)
+			if a.Target == 0 {
+				q = sellable
+			}
+			q = roundQuantity(q, params.Fractional)
+			if q > 0 && q*a.Price >= params.MinTrade {
+				sells = append(sells, Trade{Symbol: a.Symbol, Side: rh.Sell, Quantity: q, Price: a.Price})
+			}
+			continue
+		}
+		buys = append(buys, Trade{Symbol: a.Symbol, Side: rh.Buy, Quantity: diff / a.Price, Price: a.Price})
+		shortfall[a.Symbol] = diff
+	}
+
+	available := cash
+	for _, t := range sells {
+		available += t.Value()
+	}
+	available -= params.Reserve
+	sort.SliceStable(buys, func(i, j int) bool { return shortfall[buys[i].Symbol] > shortfall[buys[j].Symbol] })
+	p.Trades = sells
+	for _, t := range buys {
+		t.Quantity = roundQuantity(math.Min(t.Quantity, math.Max(available, 0)/t.Price), params.Fractional)
+		if t.Quantity <= 0 || t.Value() < params.MinTrade {
+			continue
+		}
+		available -= t.Value()
+		p.Trades = append(p.Trades, t)
+	}
+
+	p.CashAfter = cash
+	after := make(map[string]float64)
+	for _, t := range p.Trades {
+		if t.Side == rh.Sell {
+			after[t.Symbol] -= t.Quantity
+			p.CashAfter += t.Value()
+		} else {
+			after[t.Symbol] += t.Quantity
+			p.CashAfter -= t.Value()
+		}
+	}
+	for i := range p.Allocations {
+		a := &p.Allocations[i]
+		a.QuantityAfter = a.Quantity + after[a.Symbol]
+		a.ValueAfter = a.QuantityAfter * a.Price
+		a.WeightAfter = a.ValueAfter / p.Total
+	}
+	return p, nil
+}
+
+// Submit places the trades of the plan as day market orders with c.Order, and
+// returns the trades placed, even if it fails part way. Client.Order only
+// takes whole shares, so Submit places nothing if any trade is fractional.
+//
+// Sales are placed first. Since their proceeds may not be available until
+// they fill, the account's cash is read again before the purchases, which are
+// scaled down to what it covers, less the reserve, in order. Submit stops at
+// the first order that fails.
+func (p *Plan) Submit(c *rh.Client) ([]Trade, error) {
+	for _, t := range p.Trades {
+		if t.Quantity != math.Trunc(t.Quantity) {
+			return nil, fmt.Errorf("can't place an order for %v shares of %s: only whole shares are supported", t.Quantity, t.Symbol)
+		}
+	}
+	var sells, buys []Trade
+	for _, t := range p.Trades {
+		if t.Side == rh.Sell {
+			sells = append(sells, t)
+		} else {
+			buys = append(buys, t)
+		}
+	}
+	placed, err := placeOrders(c, sells, nil)
+	if err != nil || len(buys) == 0 {
+		return placed, err
+	}
+	acc, err := c.Account()
+	if err != nil {
+		return placed, err
+	}
+	return placeOrders(c, affordable(buys, availableCash(acc)-p.reserve), placed)
+}
+
+// placeOrders places trades in order, appending those placed to placed. It
+// stops at the first order that fails.
+func placeOrders(c *rh.Client, trades, placed []Trade) ([]Trade, error) {
+	for _, t := range trades {
+		err := c.Order(rh.Order{
+			Symbol:   t.Symbol,
+			Quantity: int64(t.Quantity),
+			Duration: rh.Day,
+			Type:     rh.Market,
+			Side:     t.Side,
+			Price:    t.Price,
+		})
+		if err != nil {
+			return placed, fmt.Errorf("placing order to %s %v %s: %v", t.Side, t.Quantity, t.Symbol, err)
+		}
+		placed = append(placed, t)
+	}
+	return placed, nil
+}
+
+// affordable scales down buys, in order, to whole shares that cash covers,
+// dropping those it covers none of.
+func affordable(buys []Trade, cash float64) []Trade {
+	var fit []Trade
+	for _, t := range buys {
+		t.Quantity = math.Min(t.Quantity, roundQuantity(math.Max(cash, 0)/t.Price, false))
+		if t.Quantity <= 0 {
+			continue
+		}
+		cash -= t.Value()
+		fit = append(fit, t)
+	}
+	return fit
+}
+
+// roundQuantity rounds q down to whole shares, unless fractional, and to at
+// most six decimals otherwise.
+func roundQuantity(q float64, fractional bool) float64 {
+	if !fractional {
+		return math.Floor(q + 1e-9)
+	}
+	return math.Floor(q*1e6+1e-6) / 1e6
+}
@@ -0,0 +1,181 @@
+package rebalance
+
+import (
+	"bytes"
+	"fmt"
+	"math"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	rh "github.com/edpin/robinhood"
+	httpmock "gopkg.in/jarcoal/httpmock.v1"
+)
+
+var prices = map[string]float64{"AAPL": 100, "MSFT": 200, "VTI": 40, "TSLA": 300}
+
+func TestNew(t *testing.T) {
+	positions := []rh.Position{
+		{Symbol: "AAPL", Quantity: 10},
+		{Symbol: "MSFT", Quantity: 5},
+		{Symbol: "TSLA", Quantity: 2},
+	}
+	targets := Targets{"AAPL": 0.2, "MSFT": 0.4, "VTI": 0.3}
+	tests := []struct {
+		name   string
+		params Params
+		want   []Trade
+	}{
+		{"whole shares", Params{Drift: 0.01}, []Trade{
+			{"AAPL", rh.Sell, 3, 100},
+			{"VTI", rh.Buy, 20, 40},
+		}},
+		{"fractional", Params{Drift: 0.01, Fractional: true}, []Trade{
+			{"AAPL", rh.Sell, 3.8, 100},
+			{"VTI", rh.Buy, 22, 40},
+		}},
+		{"drift", Params{Drift: 0.1}, []Trade{
+			{"AAPL", rh.Sell, 3, 100},
+			{"VTI", rh.Buy, 20, 40},
+		}},
+		{"untargeted", Params{SellUntargeted: true}, []Trade{
+			{"AAPL", rh.Sell, 3, 100},
+			{"TSLA", rh.Sell, 2, 300},
+			{"VTI", rh.Buy, 23, 40},
+			{"MSFT", rh.Buy, 1, 200},
+		}},
+		{"min trade", Params{MinTrade: 350, Drift: 0.01}, []Trade{
+			{"VTI", rh.Buy, 12, 40},
+		}},
+	}
+	for _, test := range tests {
+		p, err := New(positions, prices, 500, targets, test.params)
+		if err != nil {
+			t.Fatalf("%s: %v", test.name, err)
+		}
+		if len(p.Trades) != len(test.want) {
+			t.Errorf("%s: trades = %v, want %v", test.name, p.Trades, test.want)
+			continue
+		}
+		for i, tr := range p.Trades {
+			w := test.want[i]
+			if tr.Symbol != w.Symbol || tr.Side != w.Side || math.Abs(tr.Quantity-w.Quantity) > 1e-6 || tr.Price != w.Price {
+				t.Errorf("%s: trades[%d] = %v, want %v", test.name, i, tr, w)
+			}
+		}
+	}
+}
+
+func TestNewLimitedCash(t *testing.T) {
+	positions := []rh.Position{{Symbol: "AAPL", Quantity: 10, SharesHeldForSells: 8}}
+	p, err := New(positions, prices, 0, Targets{"AAPL": 0.5, "VTI": 0.5}, Params{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Trade{{"AAPL", rh.Sell, 2, 100}, {"VTI", rh.Buy, 5, 40}}
+	if !reflect.DeepEqual(p.Trades, want) {
+		t.Fatalf("trades = %v, want %v", p.Trades, want)
+	}
+	if p.Total != 1000 || p.CashAfter != 0 {
+		t.Errorf("total = %v, cash after = %v", p.Total, p.CashAfter)
+	}
+	a := p.Allocations[0]
+	if a.Symbol != "AAPL" || a.Weight != 1 || a.QuantityAfter != 8 || a.WeightAfter != 0.8 {
+		t.Errorf("AAPL allocation = %+v", a)
+	}
+
+	var buf bytes.Buffer
+	if err := p.WriteText(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "buy   VTI     5    40.00   200.00") {
+		t.Errorf("preview:\n%s", buf.String())
+	}
+}
+
+func TestNewUnpriced(t *testing.T) {
+	positions := []rh.Position{{Symbol: "AAPL", Quantity: 10}, {Symbol: "XYZ", Quantity: 50}}
+	p, err := New(positions, prices, 0, Targets{"AAPL": 0.5, "VTI": 0.5}, Params{SellUntargeted: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Trade{{"AAPL", rh.Sell, 5, 100}, {"VTI", rh.Buy, 12, 40}}
+	if !reflect.DeepEqual(p.Trades, want) {
+		t.Fatalf("trades = %v, want %v", p.Trades, want)
+	}
+	if p.Total != 1000 || len(p.Allocations) != 2 || !reflect.DeepEqual(p.Unpriced, []string{"XYZ"}) {
+		t.Errorf("plan = %+v", p)
+	}
+
+	var buf bytes.Buffer
+	if err := p.WriteText(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "No price for XYZ, left alone.") {
+		t.Errorf("preview:\n%s", buf.String())
+	}
+}
+
+func TestNewErrors(t *testing.T) {
+	if _, err := New(nil, prices, 1000, Targets{"AAPL": 0.6, "MSFT": 0.6}, Params{}); err == nil {
+		t.Error("targets over 1 accepted")
+	}
+	if _, err := New(nil, prices, 1000, Targets{"XYZ": 0.5}, Params{}); err == nil {
+		t.Error("target without a price accepted")
+	}
+}
+
+func TestSubmitFractional(t *testing.T) {
+	p := &Plan{Trades: []Trade{{"VTI", rh.Buy, 2.5, 40}}}
+	if _, err := p.Submit(&rh.Client{}); err == nil || !strings.Contains(err.Error(), "whole shares") {
+		t.Errorf("err = %v, want whole shares error", err)
+	}
+}
+
+func TestSubmit(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	const api = "https://api.robinhood.com/"
+	for symbol, tradability := range map[string]string{"AAPL": "tradable", "VTI": "tradable", "XYZ": "position_closing_only"} {
+		httpmock.RegisterResponder("GET", api+"instruments/?symbol="+symbol, httpmock.NewStringResponder(200, fmt.Sprintf(
+			`{"previous":null,"results":[{"id":"%[1]s","url":"%[2]sinstruments/%[1]s/","symbol":"%[1]s","tradeable":%[3]v,"tradability":"%[4]s"}],"next":null}`,
+			symbol, api, tradability == "tradable", tradability)))
+	}
+	account := `{"account_number":"ACC1","url":"https://api.robinhood.com/accounts/ACC1/","type":"cash","cash":"300.0000","buying_power":"300.0000","cash_held_for_orders":"0.0000","created_at":"2017-01-02T10:00:00Z","updated_at":"2018-01-02T10:00:00Z"}`
+	httpmock.RegisterResponder("GET", api+"accounts/", httpmock.NewStringResponder(200, `{"previous":null,"results":[`+account+`],"next":null}`))
+	httpmock.RegisterResponder("GET", api+"accounts/ACC1/", httpmock.NewStringResponder(200, account))
+	var posted []string
+	httpmock.RegisterResponder("POST", api+"orders/", func(req *http.Request) (*http.Response, error) {
+		if err := req.ParseForm(); err != nil {
+			return nil, err
+		}
+		posted = append(posted, req.PostForm.Get("side")+" "+req.PostForm.Get("quantity")+" "+req.PostForm.Get("symbol"))
+		return httpmock.NewStringResponse(200, `{"id":"o1","state":"queued"}`), nil
+	})
+
+	// XYZ is closing only: it can be sold but not bought.
+	positions := []rh.Position{{Symbol: "AAPL", Quantity: 10}, {Symbol: "XYZ", Quantity: 5}}
+	p, err := New(positions, map[string]float64{"AAPL": 100, "VTI": 40, "XYZ": 20}, 0, Targets{"AAPL": 0.5, "VTI": 0.5}, Params{SellUntargeted: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []Trade{{"AAPL", rh.Sell, 4, 100}, {"XYZ", rh.Sell, 5, 20}, {"VTI", rh.Buy, 12, 40}}
+	if !reflect.DeepEqual(p.Trades, want) {
+		t.Fatalf("trades = %v, want %v", p.Trades, want)
+	}
+
+	// The sales haven't filled: only 300 is available for purchases.
+	placed, err := p.Submit(&rh.Client{AccountID: "ACC1", Token: "token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want[2].Quantity = 7
+	if !reflect.DeepEqual(placed, want) {
+		t.Errorf("placed = %v, want %v", placed, want)
+	}
+	if wantPosted := []string{"sell 4 AAPL", "sell 5 XYZ", "buy 7 VTI"}; !reflect.DeepEqual(posted, wantPosted) {
+		t.Errorf("posted = %v, want %v", posted, wantPosted)
+	}
+}
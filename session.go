@@ -0,0 +1,137 @@
+package robinhood
+
+// This file deals with users that have several accounts.
+
+// Session is a user's authenticated connection to Robinhood, shared by all of
+// their accounts. Clients for each account, obtained with Account, share the
+// session's tokens, caches and limit of concurrent requests.
+type Session struct {
+	client *Client
+}
+
+// NewSession returns a session that authenticates as c, logging in with c's
+// Username and Password if it has no Token. The AccountID of c is ignored; c
+// can still be used directly for calls that don't depend on an account, such
+// as quotes.
+func NewSession(c *Client) (*Session, error) {
+	if c.Token == "" {
+		err := c.GetToken()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &Session{client: c}, nil
+}
+
+// Client returns the client the session authenticates as.
+func (s *Session) Client() *Client {
+	return s.client
+}
+
+// Accounts returns all accounts of the user.
+func (s *Session) Accounts() ([]Account, error) {
+	return s.client.GetAccounts()
+}
+
+// Account returns a client for the account with the given number, sharing the
+// session's authentication and caches.
+func (s *Session) Account(accountID string) *Client {
+	return &Client{AccountID: accountID, parent: s.client}
+}
+
+// AccountPortfolio is the portfolio of one account.
+type AccountPortfolio struct {
+	Account   Account
+	Positions []Position
+}
+
+// Portfolios returns the positions of every active account of the user,
+// fetched concurrently.
+//
+// As with Client.Portfolio, if some positions can't be resolved or priced,
+// Portfolios returns all portfolios along with the first
+// *UnresolvedPositionsError or *UnknownSymbolsError encountered. Otherwise,
+// if some accounts can't be parsed, it returns the portfolios of the others
+// along with the *UnparsedAccountsError.
+func (s *Session) Portfolios() ([]AccountPortfolio, error) {
+	accs, err := s.Accounts()
+	unparsed, ok := err.(*UnparsedAccountsError)
+	if err != nil && !ok {
+		return nil, err
+	}
+	var active []Account
+	for _, a := range accs {
+		if !a.Deactivated {
+			active = append(active, a)
+		}
+	}
+	portfolios := make([]AccountPortfolio, len(active))
+	partial := make([]error, len(active))
+	err = s.client.forEach(len(active), func(i int) error {
+		positions, err := s.Account(active[i].AccountNumber).Portfolio()
+		switch err.(type) {
+		case nil:
+		case *UnresolvedPositionsError, *UnknownSymbolsError:
+			partial[i] = err
+		default:
+			return err
+		}
+		portfolios[i] = AccountPortfolio{Account: active[i], Positions: positions}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, err := range partial {
+		if err != nil {
+			return portfolios, err
+		}
+	}
+	if unparsed != nil {
+		return portfolios, unparsed
+	}
+	return portfolios, nil
+}
+
+// AggregatePositions merges the positions in the same instrument across
+// portfolios into one, with the total quantity and the average price paid
+// over all of them. Weights are relative to the total market value of all
+// portfolios. Positions are in the order their instruments first appear.
+func AggregatePositions(portfolios []AccountPortfolio) []Position {
+	var merged []Position
+	index := make(map[Instrument]int)
+	for _, pf := range portfolios {
+		for _, p := range pf.Positions {
+			key := p.Instrument
+			if key == "" {
+				key = Instrument(p.Symbol)
+			}
+			i, ok := index[key]
+			if !ok {
+				index[key] = len(merged)
+				merged = append(merged, p)
+				continue
+			}
+			m := &merged[i]
+			quantity := m.Quantity + p.Quantity
+			if quantity != 0 {
+				m.BuyPrice = (m.BuyPrice*m.Quantity + p.BuyPrice*p.Quantity) / quantity
+			}
+			m.Quantity = quantity
+			m.SharesHeldForBuys += p.SharesHeldForBuys
+			m.SharesHeldForSells += p.SharesHeldForSells
+			m.SharesPendingFromOptionsEvents += p.SharesPendingFromOptionsEvents
+			if p.CreatedAt.Before(m.CreatedAt) {
+				m.CreatedAt = p.CreatedAt
+			}
+			if p.UpdatedAt.After(m.UpdatedAt) {
+				m.UpdatedAt = p.UpdatedAt
+			}
+			if m.Price == 0 {
+				m.Price = p.Price
+			}
+		}
+	}
+	revaluePositions(merged)
+	return merged
+}
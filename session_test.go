@@ -0,0 +1,91 @@
+package robinhood
+
+import (
+	"net/http"
+	"testing"
+
+	httpmock "gopkg.in/jarcoal/httpmock.v1"
+)
+
+func TestSession(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	replies := map[string]string{
+		apiURL + accountsURI: `{"previous":null,"results":[` +
+			`{"account_number":"ACC1","url":"https://api.robinhood.com/accounts/ACC1/","type":"margin","created_at":"2016-05-10T18:09:45Z","updated_at":"2016-05-10T18:09:45Z"},` +
+			`{"account_number":"ACC2","url":"https://api.robinhood.com/accounts/ACC2/","type":"cash","created_at":"2017-05-10T18:09:45Z","updated_at":"2017-05-10T18:09:45Z"},` +
+			`{"account_number":"OLD","url":"https://api.robinhood.com/accounts/OLD/","type":"cash","deactivated":true,"created_at":"2015-05-10T18:09:45Z","updated_at":"2015-05-10T18:09:45Z"}` +
+			`],"next":null}`,
+		apiURL + accountsURI + "ACC1/" + positionsURI + "?nonzero=true": `{"previous":null,"results":[` +
+			`{"average_buy_price":"100.0000","instrument":"https://api.robinhood.com/instruments/aapl/","quantity":"10.0000","shares_held_for_sells":"1.0000","created_at":"2018-03-01T00:00:00Z","updated_at":"2018-03-05T00:00:00Z"},` +
+			`{"average_buy_price":"50.0000","instrument":"https://api.robinhood.com/instruments/msft/","quantity":"20.0000","created_at":"2018-03-01T00:00:00Z","updated_at":"2018-03-01T00:00:00Z"}` +
+			`],"next":null}`,
+		apiURL + accountsURI + "ACC2/" + positionsURI + "?nonzero=true": `{"previous":null,"results":[` +
+			`{"average_buy_price":"130.0000","instrument":"https://api.robinhood.com/instruments/aapl/","quantity":"30.0000","shares_held_for_sells":"2.0000","created_at":"2018-01-01T00:00:00Z","updated_at":"2018-02-01T00:00:00Z"}` +
+			`],"next":null}`,
+		apiURL + instrumentsURI + "aapl/":         `{"id":"aapl","url":"https://api.robinhood.com/instruments/aapl/","symbol":"AAPL","simple_name":"Apple","tradeable":true}`,
+		apiURL + instrumentsURI + "msft/":         `{"id":"msft","url":"https://api.robinhood.com/instruments/msft/","symbol":"MSFT","simple_name":"Microsoft","tradeable":true}`,
+		apiURL + quotesURI + "?symbols=AAPL,MSFT": `{"results":[{"ask_price":"150.0000","bid_price":"150.0000","last_trade_price":"150.0000","symbol":"AAPL"},{"ask_price":"100.0000","bid_price":"100.0000","last_trade_price":"100.0000","symbol":"MSFT"}]}`,
+		apiURL + quotesURI + "?symbols=AAPL":      `{"results":[{"ask_price":"150.0000","bid_price":"150.0000","last_trade_price":"150.0000","symbol":"AAPL"}]}`,
+	}
+	for url, reply := range replies {
+		reply := reply
+		httpmock.RegisterResponder("GET", url, func(req *http.Request) (*http.Response, error) {
+			if got := req.Header.Get("Authorization"); got != "Token token" {
+				t.Errorf("%s: Authorization = %q, want the session's token", req.URL, got)
+			}
+			return httpmock.NewStringResponse(200, reply), nil
+		})
+	}
+	httpmock.RegisterResponder("POST", apiURL+oAuthUpgradeURI, httpmock.NewStringResponder(200,
+		`{"token_type":"Bearer","access_token":"btok","expires_in":300,"refresh_token":"reftok","scope":"web_limited"}`))
+
+	s, err := NewSession(&Client{Token: "token"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	portfolios, err := s.Portfolios()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(portfolios) != 2 || portfolios[0].Account.AccountNumber != "ACC1" || portfolios[1].Account.AccountNumber != "ACC2" {
+		t.Fatalf("portfolios = %+v, want ACC1 and ACC2", portfolios)
+	}
+	if n := len(portfolios[0].Positions); n != 2 {
+		t.Errorf("ACC1 has %d positions, want 2", n)
+	}
+
+	merged := AggregatePositions(portfolios)
+	if len(merged) != 2 {
+		t.Fatalf("len(merged) = %d, want 2", len(merged))
+	}
+	aapl := merged[0]
+	if aapl.Symbol != "AAPL" || aapl.Quantity != 40 || aapl.BuyPrice != 122.5 || aapl.SharesHeldForSells != 3 {
+		t.Errorf("AAPL = %+v", aapl)
+	}
+	if aapl.CreatedAt.Month() != 1 || aapl.UpdatedAt.Month() != 3 {
+		t.Errorf("AAPL created %v, updated %v; want earliest and latest", aapl.CreatedAt, aapl.UpdatedAt)
+	}
+	if aapl.MarketValue != 6000 || aapl.UnrealizedPL != 1100 || aapl.Weight != 0.75 {
+		t.Errorf("AAPL value %v, P&L %v, weight %v", aapl.MarketValue, aapl.UnrealizedPL, aapl.Weight)
+	}
+	if msft := merged[1]; msft.Symbol != "MSFT" || msft.Quantity != 20 || msft.Weight != 0.25 {
+		t.Errorf("MSFT = %+v", msft)
+	}
+
+	// Account clients share the session's bearer token.
+	before := httpmock.GetTotalCallCount()
+	for _, acc := range []string{"ACC1", "ACC2"} {
+		tok, err := s.Account(acc).bearerToken()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tok != "btok" {
+			t.Errorf("bearer token = %q, want btok", tok)
+		}
+	}
+	if got := httpmock.GetTotalCallCount() - before; got != 1 {
+		t.Errorf("fetched bearer token %d times, want 1", got)
+	}
+}
@@ -0,0 +1,98 @@
+package strategy
+
+// This file computes and exports profit and loss over a grid of underlying
+// prices and dates.
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"text/tabwriter"
+	"time"
+
+	"github.com/edpin/robinhood/pricing"
+)
+
+// Grid is the theoretical profit and loss of a strategy over a range of
+// underlying prices and dates.
+type Grid struct {
+	Spots []float64
+	Dates []time.Time
+	PnL   [][]float64 // PnL[i][j] is at Spots[i] on Dates[j].
+}
+
+// Grid returns the profit and loss of the strategy at each of the spot prices
+// and dates. The spot and time of m are ignored.
+func (s *Strategy) Grid(m pricing.Market, spots []float64, dates []time.Time) *Grid {
+	g := &Grid{Spots: spots, Dates: dates, PnL: make([][]float64, len(spots))}
+	for i, spot := range spots {
+		g.PnL[i] = make([]float64, len(dates))
+		for j, date := range dates {
+			m.Spot, m.Now = spot, date
+			g.PnL[i][j] = s.PnL(m)
+		}
+	}
+	return g
+}
+
+// Spots returns n evenly spaced prices from lo to hi, inclusive.
+func Spots(lo, hi float64, n int) []float64 {
+	if n < 2 {
+		return []float64{lo}
+	}
+	spots := make([]float64, n)
+	for i := range spots {
+		spots[i] = lo + (hi-lo)*float64(i)/float64(n-1)
+	}
+	return spots
+}
+
+// WriteCSV writes the grid as CSV, with one row per spot price and one column
+// per date.
+func (g *Grid) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	header := []string{"spot"}
+	for _, d := range g.Dates {
+		header = append(header, d.Format("2006-01-02"))
+	}
+	cw.Write(header)
+	for i, spot := range g.Spots {
+		row := []string{strconv.FormatFloat(spot, 'f', -1, 64)}
+		for _, pnl := range g.PnL[i] {
+			row = append(row, strconv.FormatFloat(pnl, 'f', 2, 64))
+		}
+		cw.Write(row)
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteText writes the grid as an aligned table.
+func (g *Grid) WriteText(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprint(tw, "spot\t")
+	for _, d := range g.Dates {
+		fmt.Fprintf(tw, "%s\t", d.Format("Jan 02"))
+	}
+	fmt.Fprintln(tw)
+	for i, spot := range g.Spots {
+		fmt.Fprintf(tw, "%.2f\t", spot)
+		for _, pnl := range g.PnL[i] {
+			fmt.Fprintf(tw, "%.2f\t", pnl)
+		}
+		fmt.Fprintln(tw)
+	}
+	return tw.Flush()
+}
+
+// WriteSummary writes the cost, maximum profit and loss and break-even prices
+// of the strategy at expiration.
+func (s *Strategy) WriteSummary(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintf(tw, "Cost:\t%.2f\n", s.Cost())
+	fmt.Fprintf(tw, "Max profit:\t%.2f\n", s.MaxProfit())
+	fmt.Fprintf(tw, "Max loss:\t%.2f\n", s.MaxLoss())
+	fmt.Fprintf(tw, "Break-evens:\t%.2f\n", s.BreakEvens())
+	return tw.Flush()
+}
@@ -0,0 +1,242 @@
+// Package strategy analyzes multi-leg option strategies: payoff at expiration,
+// maximum profit and loss, break-even prices, net greeks and profit and loss
+// over a grid of underlying prices and dates.
+package strategy
+
+import (
+	"math"
+	"sort"
+
+	rh "github.com/edpin/robinhood"
+	"github.com/edpin/robinhood/pricing"
+)
+
+// defaultMultiplier is the number of shares per contract when a leg doesn't
+// say.
+const defaultMultiplier = 100
+
+// Leg is a position in a single option.
+type Leg struct {
+	Chain    rh.Chain
+	Side     rh.Direction
+	Quantity float64 // Number of contracts. Always positive.
+	// Price is the premium paid (or received, if short) per share of the
+	// underlying, like rh.Option.MarketPrice.
+	Price float64
+	// Vol is the implied volatility used to value the leg before expiration.
+	Vol        float64
+	Multiplier float64 // Shares per contract. If zero, 100.
+}
+
+// OptionLeg returns a leg of quantity contracts of o, opened at its market
+// price.
+func OptionLeg(o rh.Option, side rh.Direction, quantity float64) Leg {
+	return Leg{
+		Chain:    rh.Chain{Symbol: o.Symbol, Strike: o.Strike, Expiration: o.Expiration, Type: o.Type},
+		Side:     side,
+		Quantity: quantity,
+		Price:    o.MarketPrice,
+		Vol:      o.IV,
+	}
+}
+
+// PositionLeg returns the leg of an open option position.
+func PositionLeg(p rh.OptionPosition) Leg {
+	return Leg{
+		Chain:      p.Chain,
+		Side:       p.Direction,
+		Quantity:   p.Quantity,
+		Price:      p.AveragePrice,
+		Vol:        p.Option.IV,
+		Multiplier: p.Multiplier,
+	}
+}
+
+// size returns the signed number of shares the leg controls: positive if long
+// and negative if short.
+func (l Leg) size() float64 {
+	m := l.Multiplier
+	if m == 0 {
+		m = defaultMultiplier
+	}
+	if l.Side == rh.Short {
+		return -l.Quantity * m
+	}
+	return l.Quantity * m
+}
+
+// Stock is a position in the underlying.
+type Stock struct {
+	Shares float64 // Negative if short.
+	Price  float64 // Average price per share.
+}
+
+// Strategy is a set of option legs on the same underlying, and optionally a
+// position in the underlying itself.
+type Strategy struct {
+	Legs  []Leg
+	Stock Stock
+	// Model values the legs before expiration. The zero value is
+	// pricing.BlackScholes.
+	Model pricing.Model
+}
+
+// Cost returns the net amount paid to open the strategy. It is negative for a
+// net credit.
+func (s *Strategy) Cost() float64 {
+	cost := s.Stock.Shares * s.Stock.Price
+	for _, l := range s.Legs {
+		cost += l.size() * l.Price
+	}
+	return cost
+}
+
+// Payoff returns the profit or loss of the strategy if all legs expire with
+// the underlying at spot.
+func (s *Strategy) Payoff(spot float64) float64 {
+	value := s.Stock.Shares * spot
+	for _, l := range s.Legs {
+		value += l.size() * intrinsic(l.Chain, spot)
+	}
+	return value - s.Cost()
+}
+
+func intrinsic(ch rh.Chain, spot float64) float64 {
+	if ch.Type == rh.Put {
+		return math.Max(ch.Strike-spot, 0)
+	}
+	return math.Max(spot-ch.Strike, 0)
+}
+
+// breakpoints returns the sorted prices where the slope of the payoff may
+// change: zero and every strike.
+func (s *Strategy) breakpoints() []float64 {
+	points := []float64{0}
+	for _, l := range s.Legs {
+		points = append(points, l.Chain.Strike)
+	}
+	sort.Float64s(points)
+	unique := points[:1]
+	for _, p := range points[1:] {
+		if p != unique[len(unique)-1] {
+			unique = append(unique, p)
+		}
+	}
+	return unique
+}
+
+// finalSlope returns the change in payoff per dollar of the underlying above
+// the highest strike.
+func (s *Strategy) finalSlope() float64 {
+	slope := s.Stock.Shares
+	for _, l := range s.Legs {
+		if l.Chain.Type != rh.Put {
+			slope += l.size()
+		}
+	}
+	return slope
+}
+
+// MaxProfit returns the highest profit of the strategy at expiration, or
+// +Inf if it is unlimited. It is negative if the strategy can't be profitable.
+func (s *Strategy) MaxProfit() float64 {
+	if s.finalSlope() > 0 {
+		return math.Inf(1)
+	}
+	max := math.Inf(-1)
+	for _, p := range s.breakpoints() {
+		max = math.Max(max, s.Payoff(p))
+	}
+	return max
+}
+
+// MaxLoss returns the largest loss of the strategy at expiration as a positive
+// amount, or +Inf if it is unlimited. It is negative if the strategy can't
+// lose money.
+func (s *Strategy) MaxLoss() float64 {
+	if s.finalSlope() < 0 {
+		return math.Inf(1)
+	}
+	min := math.Inf(1)
+	for _, p := range s.breakpoints() {
+		min = math.Min(min, s.Payoff(p))
+	}
+	return -min
+}
+
+// BreakEvens returns the underlying prices, in increasing order, at which the
+// strategy neither makes nor loses money at expiration.
+func (s *Strategy) BreakEvens() []float64 {
+	var bes []float64
+	add := func(p float64) {
+		if len(bes) == 0 || math.Abs(bes[len(bes)-1]-p) > 1e-9 {
+			bes = append(bes, p)
+		}
+	}
+	points := s.breakpoints()
+	for i, p := range points {
+		f := s.Payoff(p)
+		if f == 0 {
+			add(p)
+			continue
+		}
+		if i+1 < len(points) {
+			next := points[i+1]
+			g := s.Payoff(next)
+			if g != 0 && (f < 0) != (g < 0) {
+				add(p + (next-p)*f/(f-g))
+			}
+		}
+	}
+	last := points[len(points)-1]
+	f, slope := s.Payoff(last), s.finalSlope()
+	if f != 0 && slope != 0 && (f < 0) == (slope > 0) {
+		add(last - f/slope)
+	}
+	return bes
+}
+
+// Value returns the theoretical value of the strategy with the underlying at
+// m.Spot at time m.Now. Legs are valued with s.Model at their Vol; expired
+// legs are worth their intrinsic value.
+func (s *Strategy) Value(m pricing.Market) float64 {
+	value := s.Stock.Shares * m.Spot
+	for _, l := range s.Legs {
+		value += l.size() * s.price(l, m).Price
+	}
+	return value
+}
+
+// PnL returns the theoretical profit or loss of the strategy with the
+// underlying at m.Spot at time m.Now.
+func (s *Strategy) PnL(m pricing.Market) float64 {
+	return s.Value(m) - s.Cost()
+}
+
+// Greeks returns the net greeks of the strategy with the underlying at m.Spot
+// at time m.Now, in dollars per unit of change, e.g. a Delta of 50 gains $50
+// per dollar the underlying rises. Stock contributes to Delta only.
+func (s *Strategy) Greeks(m pricing.Market) rh.Greeks {
+	g := rh.Greeks{Delta: s.Stock.Shares}
+	for _, l := range s.Legs {
+		r := s.price(l, m)
+		n := l.size()
+		g.Delta += n * r.Greeks.Delta
+		g.Gamma += n * r.Greeks.Gamma
+		g.Theta += n * r.Greeks.Theta
+		g.Vega += n * r.Greeks.Vega
+		g.Rho += n * r.Greeks.Rho
+	}
+	return g
+}
+
+func (s *Strategy) price(l Leg, m pricing.Market) pricing.Result {
+	o := rh.Option{
+		Symbol:     l.Chain.Symbol,
+		Strike:     l.Chain.Strike,
+		Expiration: l.Chain.Expiration,
+		Type:       l.Chain.Type,
+		IV:         l.Vol,
+	}
+	return pricing.PriceOption(s.Model, o, m)
+}
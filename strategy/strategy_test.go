@@ -0,0 +1,186 @@
+package strategy
+
+import (
+	"bytes"
+	"encoding/csv"
+	"math"
+	"strings"
+	"testing"
+	"time"
+
+	rh "github.com/edpin/robinhood"
+	"github.com/edpin/robinhood/pricing"
+)
+
+var (
+	now = time.Date(2018, 6, 22, 20, 0, 0, 0, time.UTC)
+	exp = time.Date(2018, 7, 20, 0, 0, 0, 0, time.UTC)
+)
+
+func leg(typ rh.OptionType, strike float64, side rh.Direction, price float64) Leg {
+	return Leg{
+		Chain:    rh.Chain{Symbol: "SPY", Strike: strike, Expiration: exp, Type: typ},
+		Side:     side,
+		Quantity: 1,
+		Price:    price,
+		Vol:      0.2,
+	}
+}
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+func equal(a, b []float64) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !near(a[i], b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestPayoff(t *testing.T) {
+	inf := math.Inf(1)
+	tests := []struct {
+		name       string
+		s          Strategy
+		cost       float64
+		maxProfit  float64
+		maxLoss    float64
+		breakEvens []float64
+	}{
+		{
+			name:       "bull call spread",
+			s:          Strategy{Legs: []Leg{leg(rh.Call, 100, rh.Long, 3), leg(rh.Call, 110, rh.Short, 1)}},
+			cost:       200,
+			maxProfit:  800,
+			maxLoss:    200,
+			breakEvens: []float64{102},
+		},
+		{
+			name:       "short straddle",
+			s:          Strategy{Legs: []Leg{leg(rh.Call, 100, rh.Short, 3), leg(rh.Put, 100, rh.Short, 3)}},
+			cost:       -600,
+			maxProfit:  600,
+			maxLoss:    inf,
+			breakEvens: []float64{94, 106},
+		},
+		{
+			name:       "long put",
+			s:          Strategy{Legs: []Leg{leg(rh.Put, 100, rh.Long, 2.5)}},
+			cost:       250,
+			maxProfit:  9750,
+			maxLoss:    250,
+			breakEvens: []float64{97.5},
+		},
+		{
+			name:       "covered call",
+			s:          Strategy{Legs: []Leg{leg(rh.Call, 105, rh.Short, 2)}, Stock: Stock{Shares: 100, Price: 100}},
+			cost:       9800,
+			maxProfit:  700,
+			maxLoss:    9800,
+			breakEvens: []float64{98},
+		},
+		{
+			name:       "long call",
+			s:          Strategy{Legs: []Leg{leg(rh.Call, 100, rh.Long, 3)}},
+			cost:       300,
+			maxProfit:  inf,
+			maxLoss:    300,
+			breakEvens: []float64{103},
+		},
+	}
+	for _, test := range tests {
+		s := test.s
+		if got := s.Cost(); !near(got, test.cost) {
+			t.Errorf("%s: Cost = %v, want %v", test.name, got, test.cost)
+		}
+		if got := s.MaxProfit(); got != test.maxProfit && !near(got, test.maxProfit) {
+			t.Errorf("%s: MaxProfit = %v, want %v", test.name, got, test.maxProfit)
+		}
+		if got := s.MaxLoss(); got != test.maxLoss && !near(got, test.maxLoss) {
+			t.Errorf("%s: MaxLoss = %v, want %v", test.name, got, test.maxLoss)
+		}
+		if got := s.BreakEvens(); !equal(got, test.breakEvens) {
+			t.Errorf("%s: BreakEvens = %v, want %v", test.name, got, test.breakEvens)
+		}
+		for _, be := range test.breakEvens {
+			if got := s.Payoff(be); !near(got, 0) {
+				t.Errorf("%s: Payoff(%v) = %v, want 0", test.name, be, got)
+			}
+		}
+	}
+}
+
+func TestGreeks(t *testing.T) {
+	m := pricing.Market{Spot: 100, Rate: 0.02, Now: now}
+	call := leg(rh.Call, 100, rh.Long, 3)
+	put := leg(rh.Put, 100, rh.Long, 3)
+	s := Strategy{Legs: []Leg{call, put}}
+	g := s.Greeks(m)
+
+	years := pricing.YearsToExpiration(now, exp)
+	c := pricing.Price(pricing.BlackScholes, pricing.Params{Type: rh.Call, Spot: 100, Strike: 100, Years: years, Rate: 0.02, Vol: 0.2})
+	p := pricing.Price(pricing.BlackScholes, pricing.Params{Type: rh.Put, Spot: 100, Strike: 100, Years: years, Rate: 0.02, Vol: 0.2})
+	if !near(g.Delta, 100*(c.Greeks.Delta+p.Greeks.Delta)) || !near(g.Gamma, 200*c.Greeks.Gamma) || g.Theta >= 0 || g.Vega <= 0 {
+		t.Errorf("straddle greeks = %+v", g)
+	}
+	if got, want := s.PnL(m), 100*(c.Price+p.Price)-600; !near(got, want) {
+		t.Errorf("PnL = %v, want %v", got, want)
+	}
+
+	s.Stock = Stock{Shares: -50, Price: 100}
+	if got := s.Greeks(m).Delta; !near(got, g.Delta-50) {
+		t.Errorf("delta with short stock = %v, want %v", got, g.Delta-50)
+	}
+}
+
+func TestGrid(t *testing.T) {
+	s := Strategy{Legs: []Leg{leg(rh.Call, 100, rh.Long, 3), leg(rh.Call, 110, rh.Short, 1)}}
+	spots := Spots(90, 120, 4)
+	if !equal(spots, []float64{90, 100, 110, 120}) {
+		t.Fatalf("Spots = %v", spots)
+	}
+	expiry := time.Date(2018, 7, 20, 20, 0, 0, 0, time.UTC)
+	g := s.Grid(pricing.Market{Rate: 0.02}, spots, []time.Time{now, expiry})
+	for i, spot := range spots {
+		if got, want := g.PnL[i][1], s.Payoff(spot); !near(got, want) {
+			t.Errorf("PnL at expiration, spot %v = %v, want %v", spot, got, want)
+		}
+		if g.PnL[i][0] <= -200 || g.PnL[i][0] >= 800 {
+			t.Errorf("PnL before expiration, spot %v = %v, outside (-200, 800)", spot, g.PnL[i][0])
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := g.WriteCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 5 || rows[0][2] != "2018-07-20" || rows[4][0] != "120" || rows[4][2] != "800.00" {
+		t.Errorf("CSV = %v", rows)
+	}
+
+	buf.Reset()
+	if err := g.WriteText(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if lines := strings.Split(strings.TrimSpace(buf.String()), "\n"); len(lines) != 5 || !strings.HasSuffix(lines[1], "-200.00") {
+		t.Errorf("text =\n%s", buf.String())
+	}
+
+	buf.Reset()
+	if err := s.WriteSummary(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "Break-evens:  [102.00]") {
+		t.Errorf("summary =\n%s", buf.String())
+	}
+}
@@ -0,0 +1,156 @@
+package robinhood
+
+// This file deals with streaming quotes by polling.
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultQuoteStreamInterval is the polling interval of a QuoteStream created
+// with a zero interval.
+const DefaultQuoteStreamInterval = 5 * time.Second
+
+// QuoteStream polls quotes for a set of symbols at a fixed interval and
+// delivers a Quote whenever its bid, ask or last price changes. The first poll
+// after a symbol is added always delivers its quote.
+type QuoteStream struct {
+	client   *Client
+	interval time.Duration
+	updates  chan Quote
+	errs     chan error
+
+	mu      sync.Mutex
+	symbols map[string]bool
+	last    map[string]Quote // Last quote delivered per symbol.
+}
+
+// NewQuoteStream starts polling quotes for the given symbols every interval.
+// Polling stops, and the Updates channel is closed, when ctx is done.
+func (c *Client) NewQuoteStream(ctx context.Context, interval time.Duration, symbols ...string) *QuoteStream {
+	if interval <= 0 {
+		interval = DefaultQuoteStreamInterval
+	}
+	s := &QuoteStream{
+		client:   c,
+		interval: interval,
+		updates:  make(chan Quote),
+		errs:     make(chan error, 1),
+		symbols:  make(map[string]bool),
+		last:     make(map[string]Quote),
+	}
+	s.Add(symbols...)
+	go s.run(ctx)
+	return s
+}
+
+// Updates returns the channel on which changed quotes are delivered. It is
+// closed when the stream stops.
+func (s *QuoteStream) Updates() <-chan Quote {
+	return s.updates
+}
+
+// Errors returns a channel with errors from polling. Polling continues after
+// an error. Errors are dropped if the previous one has not been received yet.
+func (s *QuoteStream) Errors() <-chan error {
+	return s.errs
+}
+
+// Add adds symbols to the stream. They are polled from the next interval on.
+func (s *QuoteStream) Add(symbols ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, sym := range symbols {
+		s.symbols[strings.ToUpper(sym)] = true
+	}
+}
+
+// Remove removes symbols from the stream. No further quotes are delivered for
+// them.
+func (s *QuoteStream) Remove(symbols ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, sym := range symbols {
+		sym = strings.ToUpper(sym)
+		delete(s.symbols, sym)
+		delete(s.last, sym)
+	}
+}
+
+// Symbols returns the symbols currently in the stream, sorted.
+func (s *QuoteStream) Symbols() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var symbols []string
+	for sym := range s.symbols {
+		symbols = append(symbols, sym)
+	}
+	sort.Strings(symbols)
+	return symbols
+}
+
+func (s *QuoteStream) run(ctx context.Context) {
+	defer close(s.updates)
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		if !s.poll(ctx) {
+			return
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// poll fetches quotes once and delivers the changed ones. It returns false if
+// ctx is done.
+func (s *QuoteStream) poll(ctx context.Context) bool {
+	symbols := s.Symbols()
+	if len(symbols) == 0 {
+		return ctx.Err() == nil
+	}
+	quotes, err := s.client.Quote(symbols)
+	if err != nil {
+		select {
+		case s.errs <- err:
+		default:
+		}
+		// Unknown symbols don't prevent delivering the other quotes.
+		if _, ok := err.(*UnknownSymbolsError); !ok {
+			return ctx.Err() == nil
+		}
+	}
+	for _, q := range quotes {
+		if !s.changed(q) {
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return false
+		case s.updates <- q:
+		}
+	}
+	return ctx.Err() == nil
+}
+
+// changed records q as the latest quote for its symbol and reports whether it
+// differs from the previous one. Quotes for removed symbols never change.
+func (s *QuoteStream) changed(q Quote) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.symbols[q.Symbol] {
+		return false
+	}
+	prev, ok := s.last[q.Symbol]
+	if ok && prev.Bid == q.Bid && prev.Ask == q.Ask && prev.Last == q.Last {
+		return false
+	}
+	s.last[q.Symbol] = q
+	return true
+}
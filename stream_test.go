@@ -0,0 +1,74 @@
+package robinhood
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	httpmock "gopkg.in/jarcoal/httpmock.v1"
+)
+
+func TestQuoteStream(t *testing.T) {
+	httpmock.Activate()
+	defer httpmock.DeactivateAndReset()
+
+	// AAA's price changes once, on the fourth poll. BBB's never does.
+	var mu sync.Mutex
+	polls := 0
+	httpmock.RegisterResponder("GET", apiURL+quotesURI, func(req *http.Request) (*http.Response, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		polls++
+		price := "1.0000"
+		if polls >= 4 {
+			price = "2.0000"
+		}
+		var results []string
+		for _, s := range strings.Split(req.URL.Query().Get("symbols"), ",") {
+			results = append(results, fmt.Sprintf(`{"ask_price":"%s","bid_price":"%s","last_trade_price":"%s","symbol":"%s"}`, price, price, price, s))
+		}
+		return httpmock.NewStringResponse(200, `{"results":[`+strings.Join(results, ",")+`]}`), nil
+	})
+
+	c := Client{Token: "token"}
+	ctx, cancel := context.WithCancel(context.Background())
+	s := c.NewQuoteStream(ctx, time.Millisecond, "aaa")
+
+	next := func() Quote {
+		select {
+		case q := <-s.Updates():
+			return q
+		case err := <-s.Errors():
+			t.Fatal(err)
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for quote")
+		}
+		return Quote{}
+	}
+	if q := next(); q.Symbol != "AAA" || q.Last != 1 {
+		t.Fatalf("first quote = %+v, want AAA at 1", q)
+	}
+	if q := next(); q.Symbol != "AAA" || q.Last != 2 {
+		t.Fatalf("second quote = %+v, want AAA at 2", q)
+	}
+
+	s.Remove("AAA")
+	s.Add("BBB")
+	if got := s.Symbols(); len(got) != 1 || got[0] != "BBB" {
+		t.Fatalf("Symbols() = %v, want [BBB]", got)
+	}
+	if q := next(); q.Symbol != "BBB" || q.Last != 2 {
+		t.Fatalf("third quote = %+v, want BBB at 2", q)
+	}
+
+	cancel()
+	for q := range s.Updates() {
+		if q.Symbol != "BBB" {
+			t.Fatalf("unexpected quote after cancel: %+v", q)
+		}
+	}
+}
@@ -0,0 +1,63 @@
+package surface
+
+// This file exports surfaces as JSON and CSV.
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"io"
+	"strconv"
+)
+
+// WriteJSON writes the surface as JSON, including its term structure.
+func (s *Surface) WriteJSON(w io.Writer) error {
+	return json.NewEncoder(w).Encode(struct {
+		*Surface
+		TermStructure []TermPoint `json:"term_structure"`
+	}{s, s.TermStructure()})
+}
+
+// WriteCSV writes one row per contract of the surface, ordered by expiration,
+// type and strike.
+func (s *Surface) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"symbol", "expiration", "years", "type", "strike", "delta", "iv"})
+	for _, sl := range s.Slices {
+		for _, points := range [][]Point{sl.Calls, sl.Puts} {
+			for _, p := range points {
+				cw.Write([]string{
+					s.Symbol,
+					sl.Expiration.Format("2006-01-02"),
+					formatFloat(sl.Years),
+					p.Type.String(),
+					formatFloat(p.Strike),
+					formatFloat(p.Delta),
+					formatFloat(p.IV),
+				})
+			}
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteTermStructureCSV writes the term structure of the surface as CSV.
+func (s *Surface) WriteTermStructureCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"symbol", "expiration", "years", "atm_iv", "skew_25"})
+	for _, tp := range s.TermStructure() {
+		cw.Write([]string{
+			s.Symbol,
+			tp.Expiration.Format("2006-01-02"),
+			formatFloat(tp.Years),
+			formatFloat(tp.ATM),
+			formatFloat(tp.Skew25),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+func formatFloat(f float64) string {
+	return strconv.FormatFloat(f, 'f', -1, 64)
+}
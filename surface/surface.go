@@ -0,0 +1,263 @@
+// Package surface builds implied volatility surfaces from option quotes:
+// implied volatility by strike or delta and expiration, with term structure,
+// skew and at-the-money volatility.
+package surface
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	rh "github.com/edpin/robinhood"
+	"github.com/edpin/robinhood/pricing"
+)
+
+// Point is the implied volatility of a single contract.
+type Point struct {
+	Type   rh.OptionType `json:"type"`
+	Strike float64       `json:"strike"`
+	Delta  float64       `json:"delta"`
+	IV     float64       `json:"iv"`
+}
+
+// Slice is the volatility smile of a single expiration.
+type Slice struct {
+	Expiration time.Time `json:"expiration"`
+	Years      float64   `json:"years"` // Time to expiration.
+	Calls      []Point   `json:"calls"` // Ordered by strike.
+	Puts       []Point   `json:"puts"`  // Ordered by strike.
+}
+
+// Surface is the implied volatility surface of an underlying symbol.
+type Surface struct {
+	Symbol string    `json:"symbol"`
+	Spot   float64   `json:"spot"`
+	AsOf   time.Time `json:"as_of"`
+	Slices []Slice   `json:"slices"` // Ordered by expiration.
+}
+
+// Fetch builds the surface of symbol from a snapshot of its options and the
+// quote of the underlying. Only expirations selected by req are included;
+// market data is always requested.
+func Fetch(c *rh.Client, symbol string, req rh.OptionChainRequest) (*Surface, error) {
+	quotes, err := c.Quote([]string{symbol})
+	if err != nil {
+		return nil, err
+	}
+	if len(quotes) != 1 {
+		return nil, fmt.Errorf("expected one quote for symbol %s, got %d", symbol, len(quotes))
+	}
+	req.MarketData = true
+	oc, err := c.OptionChain(symbol, req)
+	if err != nil {
+		return nil, err
+	}
+	return Build(symbol, pricing.Market{Spot: quotes[0].Price(), Now: time.Now()}, oc.Options), nil
+}
+
+// Build builds a surface from option quotes. Options without an implied
+// volatility, or already expired at m.Now, are skipped. When an option has no
+// delta, it is computed with the Black-Scholes model at the option's implied
+// volatility.
+func Build(symbol string, m pricing.Market, options []rh.Option) *Surface {
+	if m.Now.IsZero() {
+		m.Now = time.Now()
+	}
+	s := &Surface{Symbol: symbol, Spot: m.Spot, AsOf: m.Now}
+	byExp := make(map[string]*Slice)
+	for _, o := range options {
+		if o.IV <= 0 || o.Symbol == "" {
+			continue
+		}
+		years := pricing.YearsToExpiration(m.Now, o.Expiration)
+		if years <= 0 {
+			continue
+		}
+		key := o.Expiration.Format("2006-01-02")
+		sl, ok := byExp[key]
+		if !ok {
+			sl = &Slice{Expiration: o.Expiration, Years: years}
+			byExp[key] = sl
+		}
+		delta := o.Greeks.Delta
+		if delta == 0 {
+			delta = pricing.PriceOption(pricing.BlackScholes, o, m).Greeks.Delta
+		}
+		p := Point{Type: o.Type, Strike: o.Strike, Delta: delta, IV: o.IV}
+		if o.Type == rh.Put {
+			sl.Puts = append(sl.Puts, p)
+		} else {
+			sl.Calls = append(sl.Calls, p)
+		}
+	}
+	for _, sl := range byExp {
+		sortByStrike(sl.Calls)
+		sortByStrike(sl.Puts)
+		s.Slices = append(s.Slices, *sl)
+	}
+	sort.Slice(s.Slices, func(i, j int) bool { return s.Slices[i].Years < s.Slices[j].Years })
+	return s
+}
+
+func sortByStrike(ps []Point) {
+	sort.Slice(ps, func(i, j int) bool { return ps[i].Strike < ps[j].Strike })
+}
+
+// Smile returns the out-of-the-money points of the slice relative to spot:
+// puts below spot and calls at or above it, which are the most liquid. When
+// only one type is quoted at a strike, it is used regardless.
+func (sl Slice) Smile(spot float64) []Point {
+	byStrike := make(map[float64]Point)
+	for _, p := range sl.Puts {
+		byStrike[p.Strike] = p
+	}
+	for _, p := range sl.Calls {
+		if _, ok := byStrike[p.Strike]; !ok || p.Strike >= spot {
+			byStrike[p.Strike] = p
+		}
+	}
+	var smile []Point
+	for _, p := range byStrike {
+		smile = append(smile, p)
+	}
+	sortByStrike(smile)
+	return smile
+}
+
+// IVAtStrike returns the implied volatility at strike, linearly interpolated
+// along the smile and flat beyond its ends.
+func (sl Slice) IVAtStrike(spot, strike float64) (float64, error) {
+	smile := sl.Smile(spot)
+	if len(smile) == 0 {
+		return 0, fmt.Errorf("no implied volatilities for %s", sl.Expiration.Format("2006-01-02"))
+	}
+	xs := make([]float64, len(smile))
+	ys := make([]float64, len(smile))
+	for i, p := range smile {
+		xs[i], ys[i] = p.Strike, p.IV
+	}
+	return interpolate(xs, ys, strike), nil
+}
+
+// ATM returns the at-the-money implied volatility: that at a strike equal to
+// spot.
+func (sl Slice) ATM(spot float64) (float64, error) {
+	return sl.IVAtStrike(spot, spot)
+}
+
+// IVAtDelta returns the implied volatility at delta, interpolated linearly in
+// delta. Positive deltas refer to calls and negative ones to puts, e.g. -0.25
+// is the 25-delta put.
+func (sl Slice) IVAtDelta(delta float64) (float64, error) {
+	points := sl.Calls
+	if delta < 0 {
+		points = sl.Puts
+	}
+	var xs, ys []float64
+	for _, p := range points {
+		if p.Delta != 0 {
+			xs = append(xs, p.Delta)
+			ys = append(ys, p.IV)
+		}
+	}
+	if len(xs) == 0 {
+		return 0, fmt.Errorf("no deltas for %s", sl.Expiration.Format("2006-01-02"))
+	}
+	// Call deltas decrease with strike, put deltas increase toward zero.
+	idx := make([]int, len(xs))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.Slice(idx, func(i, j int) bool { return xs[idx[i]] < xs[idx[j]] })
+	sx := make([]float64, len(xs))
+	sy := make([]float64, len(xs))
+	for i, j := range idx {
+		sx[i], sy[i] = xs[j], ys[j]
+	}
+	return interpolate(sx, sy, delta), nil
+}
+
+// Skew25 returns the 25-delta risk reversal: the implied volatility of the
+// 25-delta put minus that of the 25-delta call.
+func (sl Slice) Skew25() (float64, error) {
+	put, err := sl.IVAtDelta(-0.25)
+	if err != nil {
+		return 0, err
+	}
+	call, err := sl.IVAtDelta(0.25)
+	if err != nil {
+		return 0, err
+	}
+	return put - call, nil
+}
+
+// TermPoint is the at-the-money implied volatility and skew of an expiration.
+type TermPoint struct {
+	Expiration time.Time `json:"expiration"`
+	Years      float64   `json:"years"`
+	ATM        float64   `json:"atm"`
+	Skew25     float64   `json:"skew_25"` // Zero if there are no deltas.
+}
+
+// TermStructure returns the at-the-money implied volatility and skew of each
+// expiration, in order.
+func (s *Surface) TermStructure() []TermPoint {
+	var ts []TermPoint
+	for _, sl := range s.Slices {
+		atm, err := sl.ATM(s.Spot)
+		if err != nil {
+			continue
+		}
+		skew, _ := sl.Skew25()
+		ts = append(ts, TermPoint{Expiration: sl.Expiration, Years: sl.Years, ATM: atm, Skew25: skew})
+	}
+	return ts
+}
+
+// IV returns the implied volatility at any strike and expiration. Between
+// expirations, total implied variance (IV² × years) is interpolated linearly
+// in time. Before the first and after the last expiration, the nearest smile
+// is used.
+func (s *Surface) IV(expiration time.Time, strike float64) (float64, error) {
+	if len(s.Slices) == 0 {
+		return 0, fmt.Errorf("empty surface")
+	}
+	years := pricing.YearsToExpiration(s.AsOf, expiration)
+	i := sort.Search(len(s.Slices), func(i int) bool { return s.Slices[i].Years >= years })
+	if i == 0 || i == len(s.Slices) {
+		if i == len(s.Slices) {
+			i--
+		}
+		return s.Slices[i].IVAtStrike(s.Spot, strike)
+	}
+	lo, hi := s.Slices[i-1], s.Slices[i]
+	ivLo, err := lo.IVAtStrike(s.Spot, strike)
+	if err != nil {
+		return 0, err
+	}
+	ivHi, err := hi.IVAtStrike(s.Spot, strike)
+	if err != nil {
+		return 0, err
+	}
+	w := interpolate([]float64{lo.Years, hi.Years}, []float64{ivLo * ivLo * lo.Years, ivHi * ivHi * hi.Years}, years)
+	return math.Sqrt(w / years), nil
+}
+
+// interpolate linearly interpolates y at x, given points sorted by xs. It is
+// flat beyond the first and last points.
+func interpolate(xs, ys []float64, x float64) float64 {
+	if x <= xs[0] {
+		return ys[0]
+	}
+	n := len(xs)
+	if x >= xs[n-1] {
+		return ys[n-1]
+	}
+	i := sort.SearchFloat64s(xs, x)
+	if xs[i] == x {
+		return ys[i]
+	}
+	f := (x - xs[i-1]) / (xs[i] - xs[i-1])
+	return ys[i-1] + f*(ys[i]-ys[i-1])
+}
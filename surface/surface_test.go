@@ -0,0 +1,171 @@
+package surface
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"math"
+	"testing"
+	"time"
+
+	rh "github.com/edpin/robinhood"
+	"github.com/edpin/robinhood/pricing"
+)
+
+var (
+	now   = time.Date(2018, 6, 22, 20, 0, 0, 0, time.UTC)
+	near1 = time.Date(2018, 7, 22, 0, 0, 0, 0, time.UTC)
+	far   = time.Date(2018, 9, 20, 0, 0, 0, 0, time.UTC)
+)
+
+// skewed returns a smile that rises by 1 vol point for every 5 strikes below
+// 100 and falls by half as much above it.
+func skewed(base, strike float64) float64 {
+	if strike < 100 {
+		return base + (100-strike)/500
+	}
+	return base - (strike-100)/1000
+}
+
+func testOptions() []rh.Option {
+	var options []rh.Option
+	for _, exp := range []struct {
+		date time.Time
+		base float64
+	}{{near1, 0.2}, {far, 0.25}} {
+		for strike := 80.0; strike <= 120; strike += 5 {
+			for _, typ := range []rh.OptionType{rh.Call, rh.Put} {
+				options = append(options, rh.Option{
+					Symbol:     "SPY",
+					Expiration: exp.date,
+					Strike:     strike,
+					Type:       typ,
+					IV:         skewed(exp.base, strike),
+				})
+			}
+		}
+	}
+	// No implied volatility; skipped.
+	options = append(options, rh.Option{Symbol: "SPY", Expiration: far, Strike: 200, Type: rh.Call})
+	return options
+}
+
+func near(a, b, tol float64) bool {
+	return math.Abs(a-b) <= tol
+}
+
+func TestBuild(t *testing.T) {
+	s := Build("SPY", pricing.Market{Spot: 100, Now: now}, testOptions())
+	if len(s.Slices) != 2 || !s.Slices[0].Expiration.Equal(near1) || !s.Slices[1].Expiration.Equal(far) {
+		t.Fatalf("slices = %+v", s.Slices)
+	}
+	sl := s.Slices[0]
+	if len(sl.Calls) != 9 || len(sl.Puts) != 9 {
+		t.Fatalf("got %d calls and %d puts, want 9 each", len(sl.Calls), len(sl.Puts))
+	}
+	for _, p := range sl.Calls {
+		if p.Delta <= 0 || p.Delta >= 1 {
+			t.Errorf("call %v: delta %v out of range", p.Strike, p.Delta)
+		}
+	}
+	for _, p := range sl.Puts {
+		if p.Delta >= 0 || p.Delta <= -1 {
+			t.Errorf("put %v: delta %v out of range", p.Strike, p.Delta)
+		}
+	}
+
+	smile := sl.Smile(100)
+	if len(smile) != 9 || smile[0].Type != rh.Put || smile[4].Type != rh.Call || smile[8].Type != rh.Call {
+		t.Errorf("smile = %+v", smile)
+	}
+	if iv, err := sl.IVAtStrike(100, 92.5); err != nil || !near(iv, skewed(0.2, 92.5), 1e-12) {
+		t.Errorf("IVAtStrike(92.5) = %v, %v; want %v", iv, err, skewed(0.2, 92.5))
+	}
+	if iv, _ := sl.IVAtStrike(100, 50); iv != skewed(0.2, 80) {
+		t.Errorf("IVAtStrike(50) = %v, want flat extrapolation %v", iv, skewed(0.2, 80))
+	}
+	if atm, err := sl.ATM(100); err != nil || !near(atm, 0.2, 1e-12) {
+		t.Errorf("ATM = %v, %v; want 0.2", atm, err)
+	}
+
+	// The 25-delta put is further out of the money than the 25-delta call,
+	// and the smile is steeper there.
+	skew, err := sl.Skew25()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if skew <= 0 {
+		t.Errorf("Skew25 = %v, want positive", skew)
+	}
+	put, _ := sl.IVAtDelta(-0.25)
+	call, _ := sl.IVAtDelta(0.25)
+	if put <= 0.2 || call >= 0.2 || !near(skew, put-call, 1e-12) {
+		t.Errorf("25-delta put %v, call %v, skew %v", put, call, skew)
+	}
+}
+
+func TestTermStructure(t *testing.T) {
+	s := Build("SPY", pricing.Market{Spot: 100, Now: now}, testOptions())
+	ts := s.TermStructure()
+	if len(ts) != 2 || !near(ts[0].ATM, 0.2, 1e-12) || !near(ts[1].ATM, 0.25, 1e-12) {
+		t.Fatalf("TermStructure = %+v", ts)
+	}
+
+	// Halfway in time between the two expirations, total variance is the
+	// average of theirs.
+	t1, t2 := ts[0].Years, ts[1].Years
+	mid := now.Add(time.Duration((t1 + t2) / 2 * 365 * 24 * float64(time.Hour)))
+	iv, err := s.IV(mid, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := math.Sqrt((0.2*0.2*t1 + 0.25*0.25*t2) / 2 / ((t1 + t2) / 2))
+	if !near(iv, want, 1e-3) {
+		t.Errorf("IV(mid, 100) = %v, want %v", iv, want)
+	}
+	if iv, _ := s.IV(now.AddDate(0, 0, 5), 100); !near(iv, 0.2, 1e-12) {
+		t.Errorf("IV before the first expiration = %v, want 0.2", iv)
+	}
+	if _, err := (&Surface{}).IV(mid, 100); err == nil {
+		t.Error("expected error for an empty surface")
+	}
+}
+
+func TestExport(t *testing.T) {
+	s := Build("SPY", pricing.Market{Spot: 100, Now: now}, testOptions())
+
+	var buf bytes.Buffer
+	if err := s.WriteCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	rows, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(rows) != 1+36 || rows[1][0] != "SPY" || rows[1][1] != "2018-07-22" || rows[1][3] != "call" || rows[1][4] != "80" {
+		t.Errorf("CSV rows = %v", rows[:2])
+	}
+
+	buf.Reset()
+	if err := s.WriteTermStructureCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	if rows, _ := csv.NewReader(&buf).ReadAll(); len(rows) != 3 || rows[2][3] != "0.25" {
+		t.Errorf("term structure CSV = %v", rows)
+	}
+
+	buf.Reset()
+	if err := s.WriteJSON(&buf); err != nil {
+		t.Fatal(err)
+	}
+	var got struct {
+		Surface
+		TermStructure []TermPoint `json:"term_structure"`
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Symbol != "SPY" || len(got.Slices) != 2 || got.Slices[1].Puts[0].Type != rh.Put || len(got.TermStructure) != 2 {
+		t.Errorf("JSON = %s", buf.String())
+	}
+}
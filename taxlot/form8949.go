@@ -0,0 +1,78 @@
+package taxlot
+
+// This file exports realized gains in the layout of IRS Form 8949.
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+)
+
+// form8949Date is the date format of Form 8949, MM/DD/YYYY.
+const form8949Date = "01/02/2006"
+
+// WriteForm8949 writes realized gains as CSV with the columns of Form 8949:
+// short-term gains first (Part I), then long-term ones (Part II), each in the
+// order they were realized. Losses disallowed by wash sales have code W and
+// are added back as an adjustment.
+func WriteForm8949(w io.Writer, realized []Realized) error {
+	rs := make([]Realized, len(realized))
+	copy(rs, realized)
+	sort.SliceStable(rs, func(i, j int) bool { return !rs[i].LongTerm && rs[j].LongTerm })
+
+	cw := csv.NewWriter(w)
+	cw.Write([]string{
+		"part",
+		"description",
+		"date_acquired",
+		"date_sold",
+		"proceeds",
+		"cost_basis",
+		"code",
+		"adjustment",
+		"gain",
+	})
+	for _, r := range rs {
+		part, code, adjustment := "I", "", ""
+		if r.LongTerm {
+			part = "II"
+		}
+		if r.WashSale != 0 {
+			code, adjustment = "W", formatMoney(r.WashSale)
+		}
+		cw.Write([]string{
+			part,
+			description(r),
+			r.Acquired.Format(form8949Date),
+			r.Sold.Format(form8949Date),
+			formatMoney(r.Proceeds),
+			formatMoney(r.Basis),
+			code,
+			adjustment,
+			formatMoney(r.Gain()),
+		})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// description returns the description of the property sold, e.g. "10 sh
+// AAPL" or "2 SPY 07/20/2018 270.00 put".
+func description(r Realized) string {
+	quantity := strconv.FormatFloat(r.Quantity, 'f', -1, 64)
+	s := r.Security
+	if !s.IsOption() {
+		return fmt.Sprintf("%s sh %s", quantity, s.Symbol)
+	}
+	d := fmt.Sprintf("%s %s %s %.2f %s", quantity, s.Symbol, s.Expiration.Format(form8949Date), s.Strike, s.Type)
+	if r.Expired {
+		d += " (expired)"
+	}
+	return d
+}
+
+func formatMoney(f float64) string {
+	return strconv.FormatFloat(f, 'f', 2, 64)
+}
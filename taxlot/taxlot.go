@@ -0,0 +1,756 @@
+// Package taxlot reconstructs tax lots from the user's order history and
+// computes realized gains for tax reporting: cost basis under FIFO, LIFO or
+// specific identification, stock splits, short and long-term holding periods
+// and wash sales.
+package taxlot
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	rh "github.com/edpin/robinhood"
+)
+
+// epsilon is the quantity below which a lot is considered closed.
+const epsilon = 1e-9
+
+// washWindow is the number of days before and after a loss within which
+// buying the same security again makes it a wash sale.
+const washWindow = 30
+
+// Method is how the lots to close are chosen when part of a position is sold.
+type Method int
+
+// Methods of choosing lots.
+const (
+	// FIFO closes the oldest lots first.
+	FIFO Method = iota + 1
+	// LIFO closes the newest lots first.
+	LIFO
+	// SpecificID closes the lots listed by each trade, then the oldest.
+	SpecificID
+)
+
+// String returns the name of the method, e.g. "FIFO".
+func (m Method) String() string {
+	switch m {
+	case FIFO:
+		return "FIFO"
+	case LIFO:
+		return "LIFO"
+	case SpecificID:
+		return "specific ID"
+	default:
+		return "invalid method"
+	}
+}
+
+// Security identifies what was traded: a stock, or an option on one. Use Stock
+// and Option to make securities, so that the same ones compare equal.
+type Security struct {
+	Symbol string // Of the stock, or of the underlying of an option.
+	// Expiration, Strike and Type are zero for stocks.
+	Expiration time.Time
+	Strike     float64
+	Type       rh.OptionType
+}
+
+// Stock returns the security of a stock.
+func Stock(symbol string) Security {
+	return Security{Symbol: symbol}
+}
+
+// Option returns the security of an option.
+func Option(ch rh.Chain) Security {
+	exp := ch.Expiration
+	return Security{
+		Symbol:     ch.Symbol,
+		Expiration: time.Date(exp.Year(), exp.Month(), exp.Day(), 0, 0, 0, 0, time.UTC),
+		Strike:     ch.Strike,
+		Type:       ch.Type,
+	}
+}
+
+// IsOption returns whether s is an option.
+func (s Security) IsOption() bool {
+	return s.Type != 0
+}
+
+// String returns the symbol of a stock, or the underlying, expiration, strike
+// and type of an option, e.g. "SPY 2018-07-20 270 put".
+func (s Security) String() string {
+	if !s.IsOption() {
+		return s.Symbol
+	}
+	return fmt.Sprintf("%s %s %g %s", s.Symbol, s.Expiration.Format("2006-01-02"), s.Strike, s.Type)
+}
+
+// Trade is a single fill of an order.
+type Trade struct {
+	// ID identifies the trade. Lots are identified by the ID of the trade
+	// that opened them.
+	ID       string
+	Security Security
+	Time     time.Time
+	// Side is Buy or Sell for stocks and BuyToOpen, BuyToClose, SellToOpen
+	// or SellToClose for options.
+	Side     rh.Side
+	Quantity float64 // Shares or contracts. Always positive.
+	// Price is per share, or per share of the underlying for options.
+	Price float64
+	// Multiplier is the number of shares per contract. If zero, it is 1 for
+	// stocks and 100 for options.
+	Multiplier float64
+	Fees       float64
+	// Lots are the IDs of the lots to close, in order, under SpecificID. If
+	// they don't cover Quantity, the oldest other lots are closed.
+	Lots []string
+	// Exercise is, for stock bought or sold on the assignment or exercise of
+	// an option, that option.
+	Exercise *Exercise
+}
+
+// Exercise is the assignment or exercise of an option, which delivers stock.
+// The lots of the option are closed at no gain or loss, and their premium goes
+// to the stock: the premium paid for a long option is added to the basis of
+// the stock bought or taken off the proceeds of the stock sold, and the
+// premium received for a short option is taken off the basis or added to the
+// proceeds.
+type Exercise struct {
+	Option   Security
+	Quantity float64 // Contracts.
+}
+
+// Lot is an open position bought, or sold short, in a single trade.
+type Lot struct {
+	ID        string
+	Security  Security
+	Direction rh.Direction
+	// Acquired is when the lot was opened, moved earlier by the holding
+	// period of any loss disallowed by a wash sale.
+	Acquired time.Time
+	Quantity float64
+	// Basis is the cost of the lot, including fees and any disallowed loss.
+	// For short lots, it is the premium received net of fees.
+	Basis float64
+	// WashSale is the part of Basis from losses disallowed by wash sales.
+	WashSale float64
+
+	opened      time.Time
+	replacement float64 // Quantity already used to replace a wash sale.
+}
+
+// Realized is the gain or loss of closing part or all of a lot.
+type Realized struct {
+	Security  Security
+	LotID     string
+	Direction rh.Direction
+	Quantity  float64
+	Acquired  time.Time
+	Sold      time.Time // When the lot was closed.
+	Proceeds  float64
+	Basis     float64
+	// WashSale is the loss disallowed by a wash sale, as a positive amount.
+	// It is added to the basis of the lots that replaced this one.
+	WashSale float64
+	// LongTerm is whether the lot was held for more than a year. Short
+	// positions are always short term.
+	LongTerm bool
+	Expired  bool // Closed by the expiration of an option.
+}
+
+// Gain returns the gain, or loss if negative, that counts for taxes:
+// proceeds less basis, plus any disallowed loss.
+func (r Realized) Gain() float64 {
+	return r.Proceeds - r.Basis + r.WashSale
+}
+
+// Ledger is the result of replaying trades: the lots still open and the gains
+// and losses realized.
+type Ledger struct {
+	Open     []Lot      // By security, oldest first.
+	Realized []Realized // In the order they were realized.
+}
+
+// RealizedIn returns the gains and losses realized in a calendar year.
+func (l *Ledger) RealizedIn(year int) []Realized {
+	var rs []Realized
+	for _, r := range l.Realized {
+		if r.Sold.Year() == year {
+			rs = append(rs, r)
+		}
+	}
+	return rs
+}
+
+// Replay replays trades in time order, closing lots with method, and returns
+// the lots open and the gains realized as of asOf.
+//
+// Splits, keyed by symbol, change the quantity of stock lots held on their
+// execution date but not their basis. Options that expire before asOf and
+// are still open are closed at no cost. Options assigned or exercised are
+// closed by the trades of stock they deliver; see Exercise.
+//
+// A loss is a wash sale if the same security is bought within 30 days before
+// or after it, in which case the loss is disallowed and added to the basis of
+// the replacement. Only identical securities count, and only for long
+// positions.
+//
+// Replay fails if a trade closes more than is open, e.g. because shares were
+// transferred in from another broker. Such positions must be given as trades.
+func Replay(trades []Trade, splits map[string][]rh.Split, method Method, asOf time.Time) (*Ledger, error) {
+	r := &replay{
+		method:   method,
+		trades:   make([]Trade, len(trades)),
+		lots:     make(map[Security][]*Lot),
+		pending:  make(map[string][]washAdjustment),
+		replaced: make(map[string]float64),
+	}
+	copy(r.trades, trades)
+	sort.SliceStable(r.trades, func(i, j int) bool { return r.trades[i].Time.Before(r.trades[j].Time) })
+	for symbol, ss := range splits {
+		for _, s := range ss {
+			r.splits = append(r.splits, symbolSplit{symbol, s})
+		}
+	}
+	sort.SliceStable(r.splits, func(i, j int) bool {
+		return r.splits[i].ExecutionDate.Before(r.splits[j].ExecutionDate)
+	})
+
+	for i, t := range r.trades {
+		r.advance(t.Time, i)
+		err := r.trade(i)
+		if err != nil {
+			return nil, err
+		}
+	}
+	r.advance(asOf, len(r.trades))
+
+	ledger := &Ledger{Realized: r.realized}
+	for _, sec := range r.securities() {
+		for _, l := range r.lots[sec] {
+			if l.Quantity > epsilon {
+				ledger.Open = append(ledger.Open, *l)
+			}
+		}
+	}
+	return ledger, nil
+}
+
+// Fetch replays the stock and option order history of the client's account,
+// AccountID, or of every account if it is empty, with method, including the
+// assignments and exercises of options and the splits of all stocks traded.
+// See Replay.
+//
+// If the instruments of some orders or option legs can't be resolved, those
+// are left out and Fetch returns the ledger of the others along with an
+// *rh.UnresolvedInstrumentsError listing them.
+func Fetch(c *rh.Client, method Method, asOf time.Time) (*Ledger, error) {
+	orders, err := c.Orders()
+	unresolved, ok := err.(*rh.UnresolvedInstrumentsError)
+	if err != nil && !ok {
+		return nil, err
+	}
+	optionOrders, err := c.OptionOrders()
+	if e, ok := err.(*rh.UnresolvedInstrumentsError); ok {
+		if unresolved == nil {
+			unresolved = e
+		} else {
+			unresolved.Instruments = append(unresolved.Instruments, e.Instruments...)
+			unresolved.Errs = append(unresolved.Errs, e.Errs...)
+		}
+	} else if err != nil {
+		return nil, err
+	}
+	events, err := c.OptionEvents()
+	if err != nil {
+		return nil, err
+	}
+	if c.AccountID != "" {
+		orders, optionOrders, events = inAccount(c.AccountID, orders, optionOrders, events)
+	}
+	trades := Trades(orders, optionOrders, events)
+	splits := make(map[string][]rh.Split)
+	for _, t := range trades {
+		if t.Security.IsOption() {
+			continue
+		}
+		if _, ok := splits[t.Security.Symbol]; ok {
+			continue
+		}
+		ss, err := c.Splits(t.Security.Symbol)
+		if err != nil {
+			return nil, err
+		}
+		splits[t.Security.Symbol] = ss
+	}
+	ledger, err := Replay(trades, splits, method, asOf)
+	if err != nil {
+		return nil, err
+	}
+	if unresolved != nil {
+		return ledger, unresolved
+	}
+	return ledger, nil
+}
+
+// inAccount returns the orders and events of the account with the given
+// number.
+func inAccount(account string, orders []rh.OrderInfo, optionOrders []rh.OptionOrderInfo, events []rh.OptionEvent) ([]rh.OrderInfo, []rh.OptionOrderInfo, []rh.OptionEvent) {
+	var os []rh.OrderInfo
+	for _, o := range orders {
+		if rh.AccountID(o.Account) == account {
+			os = append(os, o)
+		}
+	}
+	var oos []rh.OptionOrderInfo
+	for _, o := range optionOrders {
+		if rh.AccountID(o.Account) == account {
+			oos = append(oos, o)
+		}
+	}
+	var es []rh.OptionEvent
+	for _, e := range events {
+		if rh.AccountID(e.Account) == account {
+			es = append(es, e)
+		}
+	}
+	return os, oos, es
+}
+
+// Trades returns a trade for each fill of the orders. Fees of an order are
+// split among its fills by quantity, across all legs of option orders.
+// Stock orders without a Symbol and option legs without a Chain, whose
+// instruments couldn't be resolved, are skipped.
+//
+// Assignments and exercises among events become the trades of the stock they
+// deliver, with an Exercise, or trades closing the options at the cash amount
+// if settled in cash. Expirations are left to Replay.
+func Trades(orders []rh.OrderInfo, optionOrders []rh.OptionOrderInfo, events []rh.OptionEvent) []Trade {
+	var trades []Trade
+	for _, o := range orders {
+		if o.Symbol == "" {
+			continue
+		}
+		filled := 0.0
+		for _, e := range o.Executions {
+			filled += e.Quantity
+		}
+		for _, e := range o.Executions {
+			trades = append(trades, Trade{
+				ID:         e.ID,
+				Security:   Stock(o.Symbol),
+				Time:       e.Time,
+				Side:       o.Side,
+				Quantity:   e.Quantity,
+				Price:      e.Price,
+				Multiplier: 1,
+				Fees:       o.Fees * e.Quantity / filled,
+			})
+		}
+	}
+	for _, o := range optionOrders {
+		filled := 0.0
+		for _, l := range o.Legs {
+			for _, e := range l.Executions {
+				if l.Chain.Type != 0 {
+					filled += e.Quantity
+				}
+			}
+		}
+		for _, l := range o.Legs {
+			if l.Chain.Type == 0 {
+				continue
+			}
+			for _, e := range l.Executions {
+				trades = append(trades, Trade{
+					ID:         e.ID,
+					Security:   Option(l.Chain),
+					Time:       e.Time,
+					Side:       l.Side,
+					Quantity:   e.Quantity,
+					Price:      e.Price,
+					Multiplier: 100,
+					Fees:       o.Fees * e.Quantity / filled,
+				})
+			}
+		}
+	}
+	for _, e := range events {
+		if e.Type != "assignment" && e.Type != "exercise" {
+			continue
+		}
+		// Replay the event before the option expires, even if it was
+		// recorded later.
+		date := e.Date
+		if exp := Option(e.Chain).Expiration; exp.Before(date) {
+			date = exp
+		}
+		if len(e.Components) == 0 {
+			side := rh.SellToClose
+			if e.Type == "assignment" {
+				side = rh.BuyToClose
+			}
+			trades = append(trades, Trade{
+				ID:         e.ID,
+				Security:   Option(e.Chain),
+				Time:       date,
+				Side:       side,
+				Quantity:   e.Quantity,
+				Price:      math.Abs(e.CashAmount) / (e.Quantity * 100),
+				Multiplier: 100,
+			})
+			continue
+		}
+		for k, ec := range e.Components {
+			t := Trade{
+				ID:         e.ID,
+				Security:   Stock(ec.Symbol),
+				Time:       date,
+				Side:       ec.Side,
+				Quantity:   ec.Quantity,
+				Price:      ec.Price,
+				Multiplier: 1,
+			}
+			if k == 0 {
+				t.Exercise = &Exercise{Option: Option(e.Chain), Quantity: e.Quantity}
+			} else {
+				t.ID = fmt.Sprintf("%s-%d", e.ID, k)
+			}
+			trades = append(trades, t)
+		}
+	}
+	return trades
+}
+
+type symbolSplit struct {
+	symbol string
+	rh.Split
+}
+
+// washAdjustment is a loss disallowed by a wash sale, to add to a lot not yet
+// opened.
+type washAdjustment struct {
+	quantity float64
+	amount   float64
+	held     time.Duration
+}
+
+type replay struct {
+	method   Method
+	trades   []Trade // In time order.
+	splits   []symbolSplit
+	lots     map[Security][]*Lot // In the order they were opened.
+	realized []Realized
+
+	// pending are the wash sale adjustments of trades not yet replayed, by
+	// trade ID, and replaced is the quantity of those trades they cover.
+	pending  map[string][]washAdjustment
+	replaced map[string]float64
+}
+
+// advance applies the splits and expirations up to t. next is the index of
+// the next trade to replay.
+func (r *replay) advance(t time.Time, next int) {
+	for len(r.splits) > 0 && !r.splits[0].ExecutionDate.After(t) {
+		s := r.splits[0]
+		r.splits = r.splits[1:]
+		ratio := s.Ratio()
+		for _, l := range r.lots[Stock(s.symbol)] {
+			l.Quantity *= ratio
+			l.replacement *= ratio
+		}
+	}
+	for _, sec := range r.securities() {
+		if !sec.IsOption() || sec.Expiration.AddDate(0, 0, 1).After(t) {
+			continue
+		}
+		for _, l := range r.lots[sec] {
+			if l.Quantity <= epsilon {
+				continue
+			}
+			expired := Realized{
+				Security:  sec,
+				LotID:     l.ID,
+				Direction: l.Direction,
+				Quantity:  l.Quantity,
+				Acquired:  l.Acquired,
+				Sold:      sec.Expiration,
+				Expired:   true,
+			}
+			if l.Direction == rh.Long {
+				expired.Basis = l.Basis
+			} else {
+				expired.Proceeds = l.Basis
+			}
+			l.Quantity, l.Basis, l.WashSale = 0, 0, 0
+			r.realize(expired, next)
+		}
+	}
+}
+
+// trade replays r.trades[i].
+func (r *replay) trade(i int) error {
+	t := r.trades[i]
+	mult := t.Multiplier
+	if mult == 0 {
+		mult = 1
+		if t.Security.IsOption() {
+			mult = 100
+		}
+	}
+	amount := t.Price * t.Quantity * mult
+	if t.Exercise != nil {
+		premium := r.exercise(t)
+		if t.Side == rh.Buy {
+			amount -= premium
+		} else {
+			amount += premium
+		}
+	}
+	switch t.Side {
+	case rh.Buy, rh.BuyToOpen:
+		r.open(t, rh.Long, amount+t.Fees)
+		return nil
+	case rh.SellToOpen:
+		r.open(t, rh.Short, amount-t.Fees)
+		return nil
+	case rh.Sell, rh.SellToClose:
+		return r.close(i, rh.Long, amount-t.Fees)
+	case rh.BuyToClose:
+		return r.close(i, rh.Short, amount+t.Fees)
+	default:
+		return fmt.Errorf("trade %s has invalid side %v", t.ID, t.Side)
+	}
+}
+
+// exercise closes the lots of the option assigned or exercised in the stock
+// trade t at no gain or loss, and returns their premium: the premium received,
+// or paid if negative. Contracts with no open lot, e.g. opened before the
+// order history starts, have no premium.
+func (r *replay) exercise(t Trade) float64 {
+	ex := t.Exercise
+	// Calls bought and puts sold deliver stock; puts bought and calls sold
+	// take it.
+	dir := rh.Long
+	if (ex.Option.Type == rh.Call) != (t.Side == rh.Buy) {
+		dir = rh.Short
+	}
+	premium := 0.0
+	remaining := ex.Quantity
+	for _, l := range r.choose(Trade{Security: ex.Option, Lots: t.Lots}, dir) {
+		if remaining <= epsilon {
+			break
+		}
+		q := math.Min(remaining, l.Quantity)
+		basis := l.Basis * q / l.Quantity
+		if dir == rh.Long {
+			premium -= basis
+		} else {
+			premium += basis
+		}
+		l.WashSale -= l.WashSale * q / l.Quantity
+		l.Basis -= basis
+		l.Quantity -= q
+		l.replacement = math.Min(l.replacement, l.Quantity)
+		remaining -= q
+	}
+	return premium
+}
+
+// open opens a lot for t, applying any wash sale adjustments it replaces.
+func (r *replay) open(t Trade, dir rh.Direction, basis float64) {
+	l := &Lot{
+		ID:        t.ID,
+		Security:  t.Security,
+		Direction: dir,
+		Acquired:  t.Time,
+		Quantity:  t.Quantity,
+		Basis:     basis,
+		opened:    t.Time,
+	}
+	var lots []*Lot
+	for _, adj := range r.pending[t.ID] {
+		if l.Quantity <= epsilon {
+			break
+		}
+		part := l.split(adj.quantity)
+		part.adjust(adj.amount, adj.held)
+		lots = append(lots, part)
+	}
+	delete(r.pending, t.ID)
+	r.lots[t.Security] = append(r.lots[t.Security], append(lots, l)...)
+}
+
+// close closes quantity of the open lots of direction dir with the trade
+// r.trades[i], for a total of amount: the proceeds of long lots or the cost of
+// short ones.
+func (r *replay) close(i int, dir rh.Direction, amount float64) error {
+	t := r.trades[i]
+	remaining := t.Quantity
+	for _, l := range r.choose(t, dir) {
+		if remaining <= epsilon {
+			break
+		}
+		q := math.Min(remaining, l.Quantity)
+		share := amount * q / t.Quantity
+		basis := l.Basis * q / l.Quantity
+		rl := Realized{
+			Security:  t.Security,
+			LotID:     l.ID,
+			Direction: dir,
+			Quantity:  q,
+			Acquired:  l.Acquired,
+			Sold:      t.Time,
+			Proceeds:  share,
+			Basis:     basis,
+		}
+		if dir == rh.Short {
+			rl.Proceeds, rl.Basis = basis, share
+		}
+		l.WashSale -= l.WashSale * q / l.Quantity
+		l.Basis -= basis
+		l.Quantity -= q
+		l.replacement = math.Min(l.replacement, l.Quantity)
+		remaining -= q
+		r.realize(rl, i+1)
+	}
+	if remaining > epsilon {
+		return fmt.Errorf("trade %s closes %g %s on %s, but only %g were open",
+			t.ID, t.Quantity, t.Security, t.Time.Format("2006-01-02"), t.Quantity-remaining)
+	}
+	return nil
+}
+
+// choose returns the open lots of direction dir that t closes, in the order
+// to close them.
+func (r *replay) choose(t Trade, dir rh.Direction) []*Lot {
+	var lots []*Lot
+	for _, l := range r.lots[t.Security] {
+		if l.Direction == dir && l.Quantity > epsilon {
+			lots = append(lots, l)
+		}
+	}
+	switch r.method {
+	case LIFO:
+		for i, j := 0, len(lots)-1; i < j; i, j = i+1, j-1 {
+			lots[i], lots[j] = lots[j], lots[i]
+		}
+	case SpecificID:
+		rank := make(map[string]int)
+		for i, id := range t.Lots {
+			if _, ok := rank[id]; !ok {
+				rank[id] = i
+			}
+		}
+		sort.SliceStable(lots, func(i, j int) bool {
+			ri, iok := rank[lots[i].ID]
+			rj, jok := rank[lots[j].ID]
+			if iok != jok {
+				return iok
+			}
+			return iok && ri < rj
+		})
+	}
+	return lots
+}
+
+// realize records rl, after checking whether it is a wash sale. next is the
+// index of the first trade after rl.
+func (r *replay) realize(rl Realized, next int) {
+	rl.LongTerm = rl.Direction == rh.Long && rl.Sold.After(rl.Acquired.AddDate(1, 0, 0))
+	loss := rl.Basis - rl.Proceeds
+	if rl.Direction == rh.Long && loss > 0 {
+		rl.WashSale = r.washSale(rl, loss, next)
+	}
+	r.realized = append(r.realized, rl)
+}
+
+// washSale finds the purchases that replace the lot closed in rl at a loss,
+// adds the loss to their basis, and returns the loss disallowed.
+func (r *replay) washSale(rl Realized, loss float64, next int) float64 {
+	from := rl.Sold.AddDate(0, 0, -washWindow)
+	to := rl.Sold.AddDate(0, 0, washWindow)
+	held := rl.Sold.Sub(rl.Acquired)
+	remaining := rl.Quantity
+	disallowed := 0.0
+
+	// Purchases before the loss that are still held.
+	lots := r.lots[rl.Security]
+	for k := 0; k < len(lots) && remaining > epsilon; k++ {
+		l := lots[k]
+		avail := l.Quantity - l.replacement
+		if l.ID == rl.LotID || l.Direction != rh.Long || avail <= epsilon || l.opened.Before(from) {
+			continue
+		}
+		q := math.Min(avail, remaining)
+		amount := loss * q / rl.Quantity
+		if q < l.Quantity-epsilon {
+			part := l.split(q)
+			lots = append(lots[:k], append([]*Lot{part}, lots[k:]...)...)
+			l = part
+			k++
+		}
+		l.adjust(amount, held)
+		remaining -= q
+		disallowed += amount
+	}
+	r.lots[rl.Security] = lots
+
+	// Purchases after the loss, adjusted when replayed.
+	for _, t := range r.trades[next:] {
+		if remaining <= epsilon || t.Time.After(to) {
+			break
+		}
+		if t.Security != rl.Security || (t.Side != rh.Buy && t.Side != rh.BuyToOpen) {
+			continue
+		}
+		avail := t.Quantity - r.replaced[t.ID]
+		if avail <= epsilon {
+			continue
+		}
+		q := math.Min(avail, remaining)
+		amount := loss * q / rl.Quantity
+		r.pending[t.ID] = append(r.pending[t.ID], washAdjustment{q, amount, held})
+		r.replaced[t.ID] += q
+		remaining -= q
+		disallowed += amount
+	}
+	return disallowed
+}
+
+// split moves quantity q out of l into a new lot and returns it.
+func (l *Lot) split(q float64) *Lot {
+	q = math.Min(q, l.Quantity)
+	part := *l
+	part.Quantity = q
+	part.Basis = l.Basis * q / l.Quantity
+	part.WashSale = l.WashSale * q / l.Quantity
+	part.replacement = math.Min(l.replacement, q)
+	l.Basis -= part.Basis
+	l.WashSale -= part.WashSale
+	l.replacement -= part.replacement
+	l.Quantity -= q
+	return &part
+}
+
+// adjust adds a loss disallowed by a wash sale to all of l, and the holding
+// period of the lot sold to its own.
+func (l *Lot) adjust(amount float64, held time.Duration) {
+	l.Basis += amount
+	l.WashSale += amount
+	l.Acquired = l.Acquired.Add(-held)
+	l.replacement = l.Quantity
+}
+
+// securities returns the securities with lots, in a stable order.
+func (r *replay) securities() []Security {
+	var secs []Security
+	for sec := range r.lots {
+		secs = append(secs, sec)
+	}
+	sort.Slice(secs, func(i, j int) bool { return secs[i].String() < secs[j].String() })
+	return secs
+}
@@ -0,0 +1,292 @@
+package taxlot
+
+import (
+	"bytes"
+	"math"
+	"testing"
+	"time"
+
+	rh "github.com/edpin/robinhood"
+)
+
+func date(y int, m time.Month, d int) time.Time {
+	return time.Date(y, m, d, 15, 0, 0, 0, time.UTC)
+}
+
+func near(a, b float64) bool {
+	return math.Abs(a-b) < 1e-9
+}
+
+var aapl = Stock("AAPL")
+
+func TestMethods(t *testing.T) {
+	trades := []Trade{
+		{ID: "b1", Security: aapl, Time: date(2017, 1, 2), Side: rh.Buy, Quantity: 10, Price: 100},
+		{ID: "s1", Security: aapl, Time: date(2018, 4, 2), Side: rh.Sell, Quantity: 10, Price: 130, Fees: 1, Lots: []string{"b2"}},
+		{ID: "b2", Security: aapl, Time: date(2018, 3, 1), Side: rh.Buy, Quantity: 10, Price: 120},
+	}
+	tests := []struct {
+		method   Method
+		lot      string
+		gain     float64
+		longTerm bool
+		open     string
+	}{
+		{FIFO, "b1", 299, true, "b2"},
+		{LIFO, "b2", 99, false, "b1"},
+		{SpecificID, "b2", 99, false, "b1"},
+	}
+	for _, test := range tests {
+		l, err := Replay(trades, nil, test.method, date(2018, 12, 31))
+		if err != nil {
+			t.Fatalf("%v: %v", test.method, err)
+		}
+		if len(l.Realized) != 1 || len(l.Open) != 1 {
+			t.Fatalf("%v: ledger = %+v", test.method, l)
+		}
+		r := l.Realized[0]
+		if r.LotID != test.lot || !near(r.Proceeds, 1299) || !near(r.Gain(), test.gain) || r.LongTerm != test.longTerm {
+			t.Errorf("%v: realized = %+v", test.method, r)
+		}
+		if l.Open[0].ID != test.open || l.Open[0].Quantity != 10 {
+			t.Errorf("%v: open = %+v", test.method, l.Open)
+		}
+	}
+
+	// Specific lots that don't cover the sale are followed by the oldest.
+	trades[1].Quantity = 15
+	l, err := Replay(trades, nil, SpecificID, date(2018, 12, 31))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(l.Realized) != 2 || l.Realized[0].LotID != "b2" || l.Realized[1].LotID != "b1" || l.Realized[1].Quantity != 5 {
+		t.Errorf("realized = %+v", l.Realized)
+	}
+
+	// Selling more than held fails.
+	trades[1].Quantity = 25
+	if _, err := Replay(trades, nil, FIFO, date(2018, 12, 31)); err == nil {
+		t.Error("Replay succeeded selling more than held")
+	}
+}
+
+func TestSplit(t *testing.T) {
+	trades := []Trade{
+		{ID: "b1", Security: aapl, Time: date(2018, 1, 2), Side: rh.Buy, Quantity: 10, Price: 100},
+		{ID: "s1", Security: aapl, Time: date(2018, 4, 2), Side: rh.Sell, Quantity: 15, Price: 60},
+	}
+	splits := map[string][]rh.Split{
+		"AAPL": {{ExecutionDate: time.Date(2018, 3, 1, 0, 0, 0, 0, time.UTC), Multiplier: 2, Divisor: 1}},
+	}
+	l, err := Replay(trades, splits, FIFO, date(2018, 12, 31))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(l.Realized) != 1 || !near(l.Realized[0].Basis, 750) || !near(l.Realized[0].Gain(), 150) {
+		t.Errorf("realized = %+v", l.Realized)
+	}
+	if len(l.Open) != 1 || l.Open[0].Quantity != 5 || !near(l.Open[0].Basis, 250) {
+		t.Errorf("open = %+v", l.Open)
+	}
+}
+
+func TestWashSale(t *testing.T) {
+	// Bought back after the loss.
+	trades := []Trade{
+		{ID: "b1", Security: aapl, Time: date(2018, 1, 2), Side: rh.Buy, Quantity: 10, Price: 100},
+		{ID: "s1", Security: aapl, Time: date(2018, 2, 1), Side: rh.Sell, Quantity: 10, Price: 80},
+		{ID: "b2", Security: aapl, Time: date(2018, 2, 15), Side: rh.Buy, Quantity: 10, Price: 85},
+	}
+	l, err := Replay(trades, nil, FIFO, date(2018, 12, 31))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := l.Realized[0]; !near(r.WashSale, 200) || !near(r.Gain(), 0) {
+		t.Errorf("realized = %+v", r)
+	}
+	want := Lot{ID: "b2", Security: aapl, Direction: rh.Long, Acquired: date(2018, 1, 16), Quantity: 10, Basis: 1050, WashSale: 200}
+	if len(l.Open) != 1 {
+		t.Fatalf("open = %+v", l.Open)
+	}
+	if got := l.Open[0]; got.ID != want.ID || !got.Acquired.Equal(want.Acquired) || !near(got.Basis, want.Basis) || !near(got.WashSale, want.WashSale) {
+		t.Errorf("open = %+v, want %+v", got, want)
+	}
+
+	// Bought before the loss and replacing half of it.
+	trades = []Trade{
+		{ID: "b1", Security: aapl, Time: date(2018, 1, 2), Side: rh.Buy, Quantity: 10, Price: 100},
+		{ID: "b2", Security: aapl, Time: date(2018, 1, 20), Side: rh.Buy, Quantity: 5, Price: 90},
+		{ID: "s1", Security: aapl, Time: date(2018, 2, 1), Side: rh.Sell, Quantity: 10, Price: 80},
+		{ID: "b3", Security: aapl, Time: date(2018, 4, 1), Side: rh.Buy, Quantity: 5, Price: 70},
+	}
+	l, err = Replay(trades, nil, FIFO, date(2018, 12, 31))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r := l.Realized[0]; !near(r.WashSale, 100) || !near(r.Gain(), -100) {
+		t.Errorf("realized = %+v", r)
+	}
+	if len(l.Open) != 2 {
+		t.Fatalf("open = %+v", l.Open)
+	}
+	if got := l.Open[0]; got.ID != "b2" || !got.Acquired.Equal(date(2017, 12, 21)) || !near(got.Basis, 550) {
+		t.Errorf("replacement = %+v", got)
+	}
+	if got := l.Open[1]; got.ID != "b3" || got.WashSale != 0 || !near(got.Basis, 350) {
+		t.Errorf("later lot = %+v", got)
+	}
+}
+
+func TestOptions(t *testing.T) {
+	exp := time.Date(2018, 7, 20, 0, 0, 0, 0, time.UTC)
+	short := Option(rh.Chain{Symbol: "SPY", Strike: 270, Expiration: exp, Type: rh.Put})
+	long := Option(rh.Chain{Symbol: "SPY", Strike: 265, Expiration: exp, Type: rh.Put})
+	trades := []Trade{
+		{ID: "x1", Security: short, Time: date(2018, 6, 18), Side: rh.SellToOpen, Quantity: 1, Price: 1.2},
+		{ID: "x2", Security: long, Time: date(2018, 6, 18), Side: rh.BuyToOpen, Quantity: 1, Price: 0.7},
+		{ID: "x3", Security: short, Time: date(2018, 7, 2), Side: rh.BuyToClose, Quantity: 1, Price: 0.5},
+	}
+
+	// Before expiration, the long put is still open.
+	l, err := Replay(trades, nil, FIFO, date(2018, 7, 20))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(l.Realized) != 1 || len(l.Open) != 1 || l.Open[0].Security != long {
+		t.Fatalf("ledger = %+v", l)
+	}
+	if r := l.Realized[0]; r.Direction != rh.Short || !near(r.Proceeds, 120) || !near(r.Basis, 50) || r.LongTerm {
+		t.Errorf("closed short = %+v", r)
+	}
+
+	l, err = Replay(trades, nil, FIFO, date(2018, 8, 1))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(l.Realized) != 2 || len(l.Open) != 0 {
+		t.Fatalf("ledger = %+v", l)
+	}
+	if r := l.Realized[1]; !r.Expired || !r.Sold.Equal(exp) || r.Proceeds != 0 || !near(r.Gain(), -70) {
+		t.Errorf("expired = %+v", r)
+	}
+}
+
+func TestTrades(t *testing.T) {
+	orders := []rh.OrderInfo{{
+		Symbol: "AAPL",
+		Side:   rh.Sell,
+		Fees:   0.1,
+		Executions: []rh.Execution{
+			{ID: "e1", Time: date(2018, 1, 2), Quantity: 3, Price: 100},
+			{ID: "e2", Time: date(2018, 1, 2), Quantity: 7, Price: 101},
+		},
+	}, {
+		Instrument: "https://api.robinhood.com/instruments/unknown/",
+		Side:       rh.Buy,
+		Executions: []rh.Execution{{ID: "e3", Time: date(2018, 1, 3), Quantity: 1, Price: 10}},
+	}}
+	exp := time.Date(2018, 7, 20, 0, 0, 0, 0, time.UTC)
+	optionOrders := []rh.OptionOrderInfo{{
+		Symbol: "SPY",
+		Fees:   1,
+		Legs: []rh.OptionOrderLeg{{
+			Chain:      rh.Chain{Symbol: "SPY", Strike: 270, Expiration: exp, Type: rh.Put},
+			Side:       rh.SellToOpen,
+			Executions: []rh.Execution{{ID: "x1", Time: date(2018, 6, 18), Quantity: 2, Price: 1.2}},
+		}, {
+			Chain:      rh.Chain{Symbol: "SPY", Strike: 265, Expiration: exp, Type: rh.Put},
+			Side:       rh.BuyToOpen,
+			Executions: []rh.Execution{{ID: "x2", Time: date(2018, 6, 18), Quantity: 2, Price: 0.7}},
+		}, {
+			// Unresolved.
+			Option:     "https://api.robinhood.com/options/instruments/gone/",
+			Side:       rh.BuyToOpen,
+			Executions: []rh.Execution{{ID: "x3", Time: date(2018, 6, 18), Quantity: 2, Price: 0.1}},
+		}},
+	}}
+	events := []rh.OptionEvent{{
+		ID:         "ev1",
+		Type:       "assignment",
+		Chain:      optionOrders[0].Legs[0].Chain,
+		Quantity:   2,
+		Date:       exp,
+		Components: []rh.EventComponent{{Symbol: "SPY", Side: rh.Buy, Quantity: 200, Price: 270}},
+	}, {
+		ID:       "ev2",
+		Type:     "expiration",
+		Chain:    optionOrders[0].Legs[1].Chain,
+		Quantity: 2,
+		Date:     exp,
+	}}
+	trades := Trades(orders, optionOrders, events)
+	if len(trades) != 5 {
+		t.Fatalf("trades = %+v", trades)
+	}
+	if !near(trades[0].Fees, 0.03) || !near(trades[1].Fees, 0.07) || trades[1].Security != aapl {
+		t.Errorf("stock trades = %+v", trades[:2])
+	}
+	if tr := trades[2]; tr.Security.String() != "SPY 2018-07-20 270 put" || tr.Side != rh.SellToOpen || tr.Multiplier != 100 || !near(tr.Fees, 0.5) {
+		t.Errorf("option trade = %+v", tr)
+	}
+	if tr := trades[4]; tr.Security != Stock("SPY") || tr.Side != rh.Buy || tr.Quantity != 200 ||
+		tr.Exercise == nil || tr.Exercise.Option != trades[2].Security || tr.Exercise.Quantity != 2 {
+		t.Errorf("assignment trade = %+v", tr)
+	}
+}
+
+func TestExercise(t *testing.T) {
+	exp := time.Date(2018, 7, 20, 0, 0, 0, 0, time.UTC)
+	put := Option(rh.Chain{Symbol: "SPY", Strike: 270, Expiration: exp, Type: rh.Put})
+	call := Option(rh.Chain{Symbol: "SPY", Strike: 280, Expiration: date(2018, 8, 17), Type: rh.Call})
+	spy := Stock("SPY")
+	trades := []Trade{
+		// A put sold and assigned, and the stock delivered sold again.
+		{ID: "x1", Security: put, Time: date(2018, 6, 18), Side: rh.SellToOpen, Quantity: 1, Price: 1.2, Fees: 0.5},
+		{ID: "ev1", Security: spy, Time: exp, Side: rh.Buy, Quantity: 100, Price: 270, Exercise: &Exercise{put, 1}},
+		// A call sold against the stock and assigned.
+		{ID: "x2", Security: call, Time: date(2018, 7, 23), Side: rh.SellToOpen, Quantity: 1, Price: 2},
+		{ID: "ev2", Security: spy, Time: date(2018, 7, 24), Side: rh.Sell, Quantity: 100, Price: 280, Exercise: &Exercise{call, 1}},
+	}
+
+	l, err := Replay(trades, nil, FIFO, date(2018, 12, 31))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(l.Realized) != 1 || len(l.Open) != 0 {
+		t.Fatalf("ledger = %+v", l)
+	}
+	// The premium received for the put lowers the basis, and that for the
+	// call adds to the proceeds.
+	if r := l.Realized[0]; r.Security != spy || r.LotID != "ev1" || r.Expired || !near(r.Basis, 27000-119.5) || !near(r.Proceeds, 28000+200) {
+		t.Errorf("realized = %+v", r)
+	}
+}
+
+func TestInAccount(t *testing.T) {
+	const mine, other = "https://api.robinhood.com/accounts/mine/", "https://api.robinhood.com/accounts/other/"
+	orders := []rh.OrderInfo{{ID: "o1", Account: mine}, {ID: "o2", Account: other}}
+	optionOrders := []rh.OptionOrderInfo{{ID: "oo1", Account: other}, {ID: "oo2", Account: mine}}
+	events := []rh.OptionEvent{{ID: "ev1", Account: other}}
+	os, oos, es := inAccount("mine", orders, optionOrders, events)
+	if len(os) != 1 || os[0].ID != "o1" || len(oos) != 1 || oos[0].ID != "oo2" || len(es) != 0 {
+		t.Errorf("inAccount = %+v, %+v, %+v", os, oos, es)
+	}
+}
+
+func TestWriteForm8949(t *testing.T) {
+	exp := time.Date(2018, 7, 20, 0, 0, 0, 0, time.UTC)
+	realized := []Realized{
+		{Security: aapl, Quantity: 10, Acquired: date(2017, 1, 2), Sold: date(2018, 4, 2), Proceeds: 1299, Basis: 1000, LongTerm: true},
+		{Security: Option(rh.Chain{Symbol: "SPY", Strike: 270, Expiration: exp, Type: rh.Put}), Quantity: 1, Acquired: date(2018, 6, 18), Sold: exp, Basis: 70, WashSale: 70, Expired: true},
+	}
+	var buf bytes.Buffer
+	if err := WriteForm8949(&buf, realized); err != nil {
+		t.Fatal(err)
+	}
+	want := "part,description,date_acquired,date_sold,proceeds,cost_basis,code,adjustment,gain\n" +
+		"I,1 SPY 07/20/2018 270.00 put (expired),06/18/2018,07/20/2018,0.00,70.00,W,70.00,0.00\n" +
+		"II,10 sh AAPL,01/02/2017,04/02/2018,1299.00,1000.00,,,299.00\n"
+	if got := buf.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}